@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/recolude/pose-recording/face"
+	"github.com/recolude/pose-recording/hands"
+	"github.com/recolude/pose-recording/holistic"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/pose"
+	"github.com/recolude/pose-recording/topology"
+	"github.com/recolude/rap/format"
+)
+
+// specList collects every use of a flag that can be given more than once.
+type specList []string
+
+func (sl *specList) String() string {
+	return strings.Join(*sl, "; ")
+}
+
+func (sl *specList) Set(spec string) error {
+	*sl = append(*sl, spec)
+	return nil
+}
+
+// streamer builds a recording out of frames as they're read.
+type streamer func(frames landmark.FrameReader) (format.Recording, error)
+
+// resolveModel finds the model by the short name the converter knows it by,
+// falling back to a built in topology of that name or a topology definition
+// file.
+func resolveModel(name string, models map[string]*topology.Topology) (*topology.Topology, error) {
+	if model, ok := models[name]; ok {
+		return model, nil
+	}
+	return topology.Resolve(name)
+}
+
+// convert reads the input described by the options through the streamer and
+// writes the resulting recording.
+func convert(opts options, stream streamer) error {
+	in, err := os.Open(opts.in)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	frames, err := opts.frameReader(in)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", opts.in, err)
+	}
+
+	recording, err := stream(frames)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", opts.in, err)
+	}
+	return opts.writeRecording(recording)
+}
+
+func runFace(args []string) error {
+	fs := flag.NewFlagSet("face", flag.ExitOnError)
+	opts := options{}
+	opts.register(fs, "face.json", "face tracking.rap")
+	maxMatchDistance := fs.Float64("max-face-distance", 0.2, "how far a face can move between frames and still be considered the same face")
+	modelName := fs.String("model", "mediapipe", "landmark model of the input, by name or path to a topology .json file")
+	glb := fs.String("glb", "", "path to additionally write the face meshes to as an animated binary glTF")
+	blendshapes := fs.Bool("blendshapes", false, "record ARKit's 52 blendshape coefficients for each face")
+	headPose := fs.Bool("head-pose", false, "record the position and rotation of each face's head")
+	headLocal := fs.Bool("head-local", false, "record face landmarks relative to the head's pose, implies -head-pose")
+	gaze := fs.Bool("gaze", false, "record the direction each eye looks")
+	gazeRays := fs.Float64("gaze-rays", 0, "length of the line drawn out of each eye along its gaze, implies -gaze (0 draws none)")
+	defaultBlinks := face.NewBlinkDetection()
+	blinks := fs.Bool("blinks", false, "record each eye's aspect ratio and detect blinks within it")
+	blinkClose := fs.Float64("blink-close", defaultBlinks.CloseThreshold, "eye aspect ratio an eye must fall below to be considered closed")
+	blinkOpen := fs.Float64("blink-open", defaultBlinks.OpenThreshold, "eye aspect ratio a closed eye must rise above to be considered open again")
+	blinkMinDuration := fs.Float64("blink-min-duration", defaultBlinks.MinDuration, "shortest time, in seconds, an eye must stay closed to count as a blink")
+	mouth := fs.Bool("mouth", false, "record how open and wide each face's mouth is, along with its viseme")
+	lipSync := fs.String("lip-sync", "", "path to additionally write each face's visemes to as Rhubarb Lip Sync JSON")
+	fs.Parse(args)
+
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	if *gazeRays < 0 {
+		return fmt.Errorf("gaze ray length can not be negative, got %g", *gazeRays)
+	}
+
+	if *blinkOpen < *blinkClose {
+		return fmt.Errorf("blink open threshold (%g) can not be less than its close threshold (%g)", *blinkOpen, *blinkClose)
+	}
+
+	if *blinkMinDuration < 0 {
+		return fmt.Errorf("blink minimum duration can not be negative, got %g", *blinkMinDuration)
+	}
+
+	smoothing, err := opts.smoothing()
+	if err != nil {
+		return err
+	}
+
+	model, err := resolveModel(*modelName, face.Models)
+	if err != nil {
+		return err
+	}
+
+	converter := face.NewConverter(opts.fps)
+	converter.MaxMatchDistance = *maxMatchDistance
+	converter.MaxGap = opts.maxGap
+	converter.Smoothing = smoothing
+	converter.Topology = model
+	converter.Blendshapes = *blendshapes
+	converter.HeadPose = *headPose
+	converter.HeadLocal = *headLocal
+	converter.Gaze = *gaze
+	converter.GazeRays = *gazeRays
+	if *blinks {
+		converter.Blinks = &face.BlinkDetection{
+			CloseThreshold: *blinkClose,
+			OpenThreshold:  *blinkOpen,
+			MinDuration:    *blinkMinDuration,
+		}
+	}
+	converter.Mouth = *mouth
+	if *glb == "" && *lipSync == "" {
+		return convert(opts, converter.Stream)
+	}
+
+	return convert(opts, func(frames landmark.FrameReader) (format.Recording, error) {
+		builder := converter.NewBuilder()
+		if err := landmark.Feed(frames, opts.fps, builder.Add); err != nil {
+			return nil, err
+		}
+
+		if *glb != "" {
+			if err := writeFile(*glb, builder.WriteGLB); err != nil {
+				return nil, err
+			}
+		}
+
+		if *lipSync != "" {
+			// A face whose mouth was never seen has no lip sync, but the rest
+			// of the faces and the recording are still written.
+			for faceIndex := 0; faceIndex < builder.Faces(); faceIndex++ {
+				cues := &bytes.Buffer{}
+				err := builder.WriteLipSync(cues, faceIndex)
+				if errors.Is(err, face.ErrMouthNeverSeen) {
+					fmt.Fprintf(os.Stderr, "skipping lip sync: %v\n", err)
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+
+				err = writeFile(lipSyncPath(*lipSync, faceIndex), func(out io.Writer) error {
+					_, err := cues.WriteTo(out)
+					return err
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return builder.Recording(), nil
+	})
+}
+
+// writeFile creates the file at path and hands it to write.
+func writeFile(path string, write func(out io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := write(f); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// lipSyncPath is where the lip sync of a face is written. The first face is
+// written to the path as given, while every other face has its index
+// appended to the path's name.
+func lipSyncPath(path string, faceIndex int) string {
+	if faceIndex == 0 {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), faceIndex, ext)
+}
+
+func runPose(args []string) error {
+	fs := flag.NewFlagSet("pose", flag.ExitOnError)
+	opts := options{}
+	opts.register(fs, "pose.json", "pose tracking.rap")
+	opts.registerOpenPose(fs)
+	modelName := fs.String("model", "mediapipe", "landmark model of the input (mediapipe, body25, coco18), by name or path to a topology .json file")
+	bvh := fs.String("bvh", "", "path to additionally write the pose to as a BVH motion capture file")
+	jointAngles := fs.Bool("joint-angles", false, "record the model's joint angles, such as elbow and knee flexion")
+	rom := fs.Bool("rom", false, "print the range of motion of each joint angle, implies -joint-angles")
+	var reps specList
+	fs.Var(&reps, "reps", "count repetitions of a joint angle or landmark axis, such as \"Right Knee Flexion:rest=30,peak=90\" or \"LEFT WRIST.y:rest=0.5,peak=0.2\" (repeatable)")
+	fs.Parse(args)
+
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	model, err := resolveModel(*modelName, pose.Models)
+	if err != nil {
+		return err
+	}
+
+	if *bvh != "" && model.Name != pose.MediaPipe.Name {
+		return fmt.Errorf("BVH export requires the %s model", pose.MediaPipe.Name)
+	}
+
+	smoothing, err := opts.smoothing()
+	if err != nil {
+		return err
+	}
+
+	converter := pose.NewConverter(opts.fps)
+	converter.MaxGap = opts.maxGap
+	converter.Smoothing = smoothing
+	converter.Topology = model
+
+	// OpenPose keypoints are in image coordinates rather than mediapipe's
+	// world coordinates, so they're centered like faces and hands are.
+	if opts.openPose() {
+		converter.Bounds = landmark.NewAABB()
+	}
+
+	converter.JointAngles = *jointAngles || *rom
+	for _, spec := range reps {
+		counter, err := pose.ParseRepCounter(spec, model)
+		if err != nil {
+			return err
+		}
+		converter.RepCounters = append(converter.RepCounters, counter)
+	}
+	if *bvh == "" && !*rom {
+		return convert(opts, converter.Stream)
+	}
+
+	return convert(opts, func(frames landmark.FrameReader) (format.Recording, error) {
+		builder := converter.NewBuilder()
+		if err := landmark.Feed(frames, opts.fps, builder.Add); err != nil {
+			return nil, err
+		}
+
+		if *bvh != "" {
+			if err := writeFile(*bvh, builder.WriteBVH); err != nil {
+				return nil, err
+			}
+		}
+
+		if *rom {
+			printRangesOfMotion(builder.RangesOfMotion())
+		}
+		return builder.Recording(), nil
+	})
+}
+
+// printRangesOfMotion prints a table of how far each joint angle moved.
+func printRangesOfMotion(ranges []pose.RangeOfMotion) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "joint\tmin\tmax\trange\tframes")
+	for _, r := range ranges {
+		fmt.Fprintf(w, "%s\t%.1f\t%.1f\t%.1f\t%d\n", r.Name, r.Min, r.Max, r.Range(), r.Frames)
+	}
+	w.Flush()
+}
+
+func runHands(args []string) error {
+	fs := flag.NewFlagSet("hands", flag.ExitOnError)
+	opts := options{}
+	opts.register(fs, "hands.json", "hand tracking.rap")
+	maxMatchDistance := fs.Float64("max-hand-distance", 0.2, "how far a hand can move between frames and still be considered the same hand")
+	modelName := fs.String("model", "mediapipe", "landmark model of the input, by name or path to a topology .json file")
+	fs.Parse(args)
+
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	smoothing, err := opts.smoothing()
+	if err != nil {
+		return err
+	}
+
+	model, err := resolveModel(*modelName, hands.Models)
+	if err != nil {
+		return err
+	}
+
+	converter := hands.NewConverter(opts.fps)
+	converter.MaxMatchDistance = *maxMatchDistance
+	converter.MaxGap = opts.maxGap
+	converter.Smoothing = smoothing
+	converter.Topology = model
+	return convert(opts, converter.Stream)
+}
+
+func runHolistic(args []string) error {
+	fs := flag.NewFlagSet("holistic", flag.ExitOnError)
+	opts := options{}
+	opts.register(fs, "holistic.json", "holistic tracking.rap")
+	fs.Parse(args)
+
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	smoothing, err := opts.smoothing()
+	if err != nil {
+		return err
+	}
+
+	converter := holistic.NewConverter(opts.fps)
+	converter.MaxGap = opts.maxGap
+	converter.Smoothing = smoothing
+	return convert(opts, converter.Stream)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `landmarks builds recolude recordings from mediapipe landmark data.
+
+Usage:
+
+	landmarks <command> [flags]
+
+Commands:
+
+	face       convert face mesh landmarks (face.py output)
+	pose       convert pose landmarks (pose.py output)
+	hands      convert hand landmarks (hands.py output)
+	holistic   convert pose, face and hand landmarks together (holistic.py output)
+
+Run "landmarks <command> -h" to see the flags a command accepts.
+`
+
+func printUsage() {
+	fmt.Fprint(os.Stderr, usage)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "face":
+		err = runFace(os.Args[2:])
+
+	case "pose":
+		err = runPose(os.Args[2:])
+
+	case "hands":
+		err = runHands(os.Args[2:])
+
+	case "holistic":
+		err = runHolistic(os.Args[2:])
+
+	case "help", "-h", "-help", "--help":
+		printUsage()
+		return
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/simplify"
+	"github.com/recolude/pose-recording/table"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/encoding"
+	enumEncoder "github.com/recolude/rap/format/encoding/enum"
+	eulerEncoder "github.com/recolude/rap/format/encoding/euler"
+	eventEncoder "github.com/recolude/rap/format/encoding/event"
+	floatEncoder "github.com/recolude/rap/format/encoding/float"
+	positionEncoder "github.com/recolude/rap/format/encoding/position"
+	rapio "github.com/recolude/rap/format/io"
+)
+
+var positionTechniques = map[string]positionEncoder.StorageTechnique{
+	"raw64": positionEncoder.Raw64,
+	"raw32": positionEncoder.Raw32,
+	"oct48": positionEncoder.Oct48,
+	"oct24": positionEncoder.Oct24,
+}
+
+var timeTechniques = map[string]rapio.TimeStorageTechnique{
+	"raw64": rapio.Raw64,
+	"raw32": rapio.Raw32,
+	"bst16": rapio.BST16,
+}
+
+// options are the flags shared by every conversion command.
+type options struct {
+	in       string
+	out      string
+	fps      float64
+	position string
+	time     string
+	compress bool
+	maxGap   float64
+	filter   string
+	simplify float64
+	format   string
+
+	csv       string
+	csvLayout string
+
+	// imageWidth and imageHeight are the size of the source video OpenPose
+	// keypoints were found in, only registered by commands that accept them.
+	imageWidth  float64
+	imageHeight float64
+}
+
+func (o *options) register(fs *flag.FlagSet, defaultIn, defaultOut string) {
+	fs.StringVar(&o.in, "in", defaultIn, "path to the landmarks to convert")
+	fs.StringVar(&o.format, "format", "auto", "format of the input (auto, json, jsonl, proto, openpose)")
+	fs.StringVar(&o.out, "out", defaultOut, "path to write the recording to")
+	fs.Float64Var(&o.fps, "fps", 30, "frame rate used to time frames that carry no timestamp")
+	fs.StringVar(&o.position, "position-encoding", "oct24", "position storage technique (raw64, raw32, oct48, oct24)")
+	fs.StringVar(&o.time, "time-encoding", "bst16", "time storage technique (raw64, raw32, bst16)")
+	fs.BoolVar(&o.compress, "compress", true, "compress the recording")
+	fs.StringVar(&o.filter, "filter", "", "smoothing filter and parameters, such as \"one-euro:min-cutoff=1,beta=10\" (ema, one-euro, savitzky-golay, kalman)")
+	fs.Float64Var(&o.simplify, "simplify", 0, "drop captures within this distance of interpolating their neighbors (0 disables)")
+	fs.Float64Var(&o.maxGap, "max-gap", 0, "seconds a subject can go undetected before it's considered lost (0 derives it from fps)")
+	fs.StringVar(&o.csv, "csv", "", "path to additionally write every landmark's trajectory to as CSV")
+	fs.StringVar(&o.csvLayout, "csv-layout", "long", "layout of the CSV (long, wide)")
+}
+
+// registerOpenPose registers the flags needed to read OpenPose keypoints.
+func (o *options) registerOpenPose(fs *flag.FlagSet) {
+	fs.Float64Var(&o.imageWidth, "image-width", 1920, "width, in pixels, of the video OpenPose keypoints were found in")
+	fs.Float64Var(&o.imageHeight, "image-height", 1080, "height, in pixels, of the video OpenPose keypoints were found in")
+}
+
+func (o options) validate() error {
+	if o.fps <= 0 {
+		return fmt.Errorf("fps must be greater than 0, got %g", o.fps)
+	}
+
+	if o.simplify < 0 {
+		return fmt.Errorf("simplify epsilon can not be negative, got %g", o.simplify)
+	}
+
+	if o.maxGap < 0 {
+		return fmt.Errorf("max gap can not be negative, got %g", o.maxGap)
+	}
+
+	switch o.format {
+	case "auto", "json", "jsonl", "proto", "openpose":
+	default:
+		return fmt.Errorf("unknown input format %q", o.format)
+	}
+
+	if _, err := table.ParseLayout(o.csvLayout); err != nil {
+		return err
+	}
+
+	if _, ok := positionTechniques[o.position]; !ok {
+		return fmt.Errorf("unknown position encoding %q", o.position)
+	}
+
+	if _, ok := timeTechniques[o.time]; !ok {
+		return fmt.Errorf("unknown time encoding %q", o.time)
+	}
+
+	return nil
+}
+
+// smoothing builds the filter factory described by the filter flag, or nil
+// if no filter was requested.
+func (o options) smoothing() (filter.Factory, error) {
+	if o.filter == "" {
+		return nil, nil
+	}
+	return filter.Parse(o.filter)
+}
+
+// openPose is whether the input is a directory of OpenPose keypoints, either
+// by request or, when the format is left to be detected, by being a
+// directory.
+func (o options) openPose() bool {
+	if o.format != "auto" {
+		return o.format == "openpose"
+	}
+	info, err := os.Stat(o.in)
+	return err == nil && info.IsDir()
+}
+
+// frameReader builds the reader for the input's format. When the format is
+// left to be detected automatically, directories are read as OpenPose
+// output, protobufs are identified by extension and everything else by
+// content.
+func (o options) frameReader(in io.Reader) (landmark.FrameReader, error) {
+	if o.openPose() {
+		if o.imageWidth == 0 && o.imageHeight == 0 {
+			return nil, errors.New("OpenPose keypoints can only be converted by the pose command")
+		}
+		return landmark.NewOpenPoseReader(o.in, o.imageWidth, o.imageHeight)
+	}
+
+	switch o.format {
+	case "json":
+		return landmark.NewDecoder(in), nil
+
+	case "jsonl":
+		return landmark.NewLinesDecoder(in), nil
+
+	case "proto":
+		return landmark.NewProtoDecoder(in), nil
+	}
+
+	switch strings.ToLower(filepath.Ext(o.in)) {
+	case ".pb", ".binarypb":
+		return landmark.NewProtoDecoder(in), nil
+	}
+	return landmark.NewReader(in)
+}
+
+func (o options) recordingWriter(out io.Writer) rapio.Writer {
+	return rapio.NewWriter(
+		[]encoding.Encoder{
+			positionEncoder.NewEncoder(positionTechniques[o.position]),
+			eventEncoder.NewEncoder(),
+			floatEncoder.NewEncoder(floatEncoder.Raw32),
+			eulerEncoder.NewEncoder(eulerEncoder.Raw32),
+			enumEncoder.NewEncoder(),
+		},
+		o.compress,
+		out,
+		timeTechniques[o.time],
+	)
+}
+
+// simplifyRecording runs keyframe reduction over the recording if requested,
+// reporting how much smaller the recording became.
+func (o options) simplifyRecording(recording format.Recording) (format.Recording, error) {
+	if o.simplify == 0 {
+		return recording, nil
+	}
+
+	sizeBefore, err := o.recordingWriter(ioutil.Discard).Write(recording)
+	if err != nil {
+		return nil, err
+	}
+
+	simplified, report := simplify.Recording(recording, o.simplify)
+
+	sizeAfter, err := o.recordingWriter(ioutil.Discard).Write(simplified)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("simplified %s, %d -> %d bytes\n", report, sizeBefore, sizeAfter)
+	return simplified, nil
+}
+
+// writeTable writes the landmark trajectories within the recording as CSV, if
+// requested.
+func (o options) writeTable(recording format.Recording) error {
+	if o.csv == "" {
+		return nil
+	}
+
+	layout, err := table.ParseLayout(o.csvLayout)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(o.csv)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := table.Write(f, recording, layout); err != nil {
+		return fmt.Errorf("unable to write %s: %w", o.csv, err)
+	}
+	return nil
+}
+
+func (o options) writeRecording(recording format.Recording) error {
+	// The table is written before simplification so every capture is kept.
+	if err := o.writeTable(recording); err != nil {
+		return err
+	}
+
+	recording, err := o.simplifyRecording(recording)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(o.out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = o.recordingWriter(f).Write(recording)
+	return err
+}
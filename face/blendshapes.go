@@ -0,0 +1,234 @@
+package face
+
+import (
+	"math"
+
+	"github.com/EliCDavis/vector"
+)
+
+// BlendshapeNames are the 52 blendshape locations ARKit reports for a face,
+// in ARKit's order. Each face's coefficients are recorded as float
+// collections named after them.
+var BlendshapeNames = []string{
+	"browDownLeft",
+	"browDownRight",
+	"browInnerUp",
+	"browOuterUpLeft",
+	"browOuterUpRight",
+	"cheekPuff",
+	"cheekSquintLeft",
+	"cheekSquintRight",
+	"eyeBlinkLeft",
+	"eyeBlinkRight",
+	"eyeLookDownLeft",
+	"eyeLookDownRight",
+	"eyeLookInLeft",
+	"eyeLookInRight",
+	"eyeLookOutLeft",
+	"eyeLookOutRight",
+	"eyeLookUpLeft",
+	"eyeLookUpRight",
+	"eyeSquintLeft",
+	"eyeSquintRight",
+	"eyeWideLeft",
+	"eyeWideRight",
+	"jawForward",
+	"jawLeft",
+	"jawOpen",
+	"jawRight",
+	"mouthClose",
+	"mouthDimpleLeft",
+	"mouthDimpleRight",
+	"mouthFrownLeft",
+	"mouthFrownRight",
+	"mouthFunnel",
+	"mouthLeft",
+	"mouthLowerDownLeft",
+	"mouthLowerDownRight",
+	"mouthPressLeft",
+	"mouthPressRight",
+	"mouthPucker",
+	"mouthRight",
+	"mouthRollLower",
+	"mouthRollUpper",
+	"mouthShrugLower",
+	"mouthShrugUpper",
+	"mouthSmileLeft",
+	"mouthSmileRight",
+	"mouthStretchLeft",
+	"mouthStretchRight",
+	"mouthUpperUpLeft",
+	"mouthUpperUpRight",
+	"noseSneerLeft",
+	"noseSneerRight",
+	"tongueOut",
+}
+
+// ramp maps value to 0 at rest and 1 at full, clamping anything beyond
+// either end. full may be less than rest for measurements that shrink as the
+// blendshape engages.
+func ramp(value, rest, full float64) float64 {
+	return math.Max(0, math.Min(1, (value-rest)/(full-rest)))
+}
+
+// faceSide is the landmarks of a single side of the face.
+type faceSide struct {
+	suffix string
+
+	eyeOuter, eyeInner, eyeUpper, eyeLower, iris int
+	eyeUpperOuter, eyeUpperInner                 int
+	eyeLowerOuter, eyeLowerInner                 int
+	browInner, browOuter                         int
+	cheek, nostril                               int
+	mouthCorner, upperLip, lowerLip              int
+
+	// outward is the direction, along the head's X axis, the side lies in.
+	outward float64
+}
+
+var faceSides = []faceSide{
+	{
+		suffix:   "Left",
+		eyeOuter: leftEyeOuter, eyeInner: leftEyeInner, eyeUpper: leftEyeUpper, eyeLower: leftEyeLower, iris: leftIrisCenter,
+		eyeUpperOuter: leftEyeUpperOuter, eyeUpperInner: leftEyeUpperInner,
+		eyeLowerOuter: leftEyeLowerOuter, eyeLowerInner: leftEyeLowerInner,
+		browInner: leftBrowInner, browOuter: leftBrowOuter,
+		cheek: leftCheek, nostril: leftNostril,
+		mouthCorner: mouthLeftCorner, upperLip: upperLipLeft, lowerLip: lowerLipLeft,
+		outward: 1,
+	},
+	{
+		suffix:   "Right",
+		eyeOuter: rightEyeOuter, eyeInner: rightEyeInner, eyeUpper: rightEyeUpper, eyeLower: rightEyeLower, iris: rightIrisCenter,
+		eyeUpperOuter: rightEyeUpperOuter, eyeUpperInner: rightEyeUpperInner,
+		eyeLowerOuter: rightEyeLowerOuter, eyeLowerInner: rightEyeLowerInner,
+		browInner: rightBrowInner, browOuter: rightBrowOuter,
+		cheek: rightCheek, nostril: rightNostril,
+		mouthCorner: mouthRightCorner, upperLip: upperLipRight, lowerLip: lowerLipRight,
+		outward: -1,
+	},
+}
+
+// blendshapeLandmarks are every landmark the blendshapes are measured from,
+// other than the irises, which only the eyeLook blendshapes need.
+var blendshapeLandmarks = []int{
+	noseTip, noseBottom, chin,
+	rightEyeOuter, rightEyeInner, rightEyeUpper, rightEyeLower,
+	leftEyeOuter, leftEyeInner, leftEyeUpper, leftEyeLower,
+	rightBrowInner, rightBrowOuter, leftBrowInner, leftBrowOuter,
+	rightNostril, leftNostril, rightCheek, leftCheek,
+	mouthRightCorner, mouthLeftCorner,
+	upperLipTop, upperLipInner, lowerLipInner, lowerLipBottom,
+	upperLipRight, upperLipLeft, lowerLipRight, lowerLipLeft,
+}
+
+// blendshapes estimates ARKit's blendshape coefficients, by name, from the
+// geometry of a single frame of a face. Every measurement is taken within the
+// head's frame, with heights against the height of the head so the video's
+// aspect ratio doesn't skew them, and the ranges each blendshape ramps across
+// are those of a typical adult face. ok is false if any of the landmarks the blendshapes are
+// measured from are missing.
+func blendshapes(fp facePoints) (weights map[string]float64, ok bool) {
+	hf, ok := newHeadFrame(fp)
+	if !ok || !fp.has(blendshapeLandmarks...) {
+		return nil, false
+	}
+	at := func(i int) vector.Vector3 {
+		return hf.measured(fp.pos[i])
+	}
+	weights = make(map[string]float64, len(BlendshapeNames))
+
+	browInnerHeight := (at(leftBrowInner).Y() - at(leftEyeInner).Y() + at(rightBrowInner).Y() - at(rightEyeInner).Y()) / 2
+	weights["browInnerUp"] = ramp(browInnerHeight, 0.23, 0.32)
+	weights["cheekPuff"] = ramp(at(leftCheek).Distance(at(rightCheek)), 0.80, 0.88)
+
+	nose := at(noseTip)
+	lipCenter := at(upperLipInner).Add(at(lowerLipInner)).DivByConstant(2)
+	gap := at(upperLipInner).Distance(at(lowerLipInner))
+	mouthWidth := at(mouthLeftCorner).Distance(at(mouthRightCorner))
+
+	// The lips part as the jaw opens, while the chin dropping away from the
+	// nose follows the jaw even when the lips are held together.
+	jawOpen := ramp(gap, 0.02, 0.40)
+	jawDrop := ramp(nose.Y()-at(chin).Y(), 0.95, 1.30)
+	weights["jawOpen"] = math.Max(jawOpen, jawDrop)
+	weights["mouthClose"] = math.Max(0, jawDrop-jawOpen)
+	weights["jawLeft"] = ramp(at(chin).X()-nose.X(), 0.03, 0.15)
+	weights["jawRight"] = ramp(at(chin).X()-nose.X(), -0.03, -0.15)
+	weights["jawForward"] = ramp(at(chin).Z()-at(upperLipTop).Z(), -0.08, 0.02)
+
+	weights["mouthLeft"] = ramp(lipCenter.X()-nose.X(), 0.02, 0.12)
+	weights["mouthRight"] = ramp(lipCenter.X()-nose.X(), -0.02, -0.12)
+
+	parted := ramp(gap, 0.02, 0.12)
+	narrow := ramp(mouthWidth, 0.52, 0.40)
+	weights["mouthPucker"] = narrow * (1 - parted)
+	weights["mouthFunnel"] = narrow * parted
+
+	// Rolling the lips in hides their red, thinning them.
+	weights["mouthRollUpper"] = ramp(at(upperLipTop).Distance(at(upperLipInner)), 0.08, 0.03)
+	weights["mouthRollLower"] = ramp(at(lowerLipInner).Distance(at(lowerLipBottom)), 0.10, 0.04)
+	weights["mouthShrugUpper"] = ramp(at(noseBottom).Distance(at(upperLipTop)), 0.15, 0.10)
+	weights["mouthShrugLower"] = ramp(at(lowerLipBottom).Distance(at(chin)), 0.35, 0.45)
+
+	for _, side := range faceSides {
+		outer, inner := at(side.eyeOuter), at(side.eyeInner)
+		corners := outer.Add(inner).DivByConstant(2)
+		width := outer.Distance(inner)
+
+		// How open the eye is, relative to how wide it is.
+		opening := at(side.eyeUpper).Distance(at(side.eyeLower)) / width
+		weights["eyeBlink"+side.suffix] = ramp(opening, 0.26, 0.08)
+		weights["eyeWide"+side.suffix] = ramp(opening, 0.32, 0.45)
+
+		// Squinting raises the lower lid towards the corners of the eye.
+		weights["eyeSquint"+side.suffix] = ramp((corners.Y()-at(side.eyeLower).Y())/width, 0.11, 0.05)
+
+		if fp.has(side.iris) {
+			iris := at(side.iris)
+
+			// How far along the eye, from its inner corner to its outer,
+			// the iris sits. Looking straight ahead puts it halfway.
+			along := iris.Sub(inner).Dot(outer.Sub(inner)) / (width * width)
+			weights["eyeLookIn"+side.suffix] = ramp(along, 0.5, 0.32)
+			weights["eyeLookOut"+side.suffix] = ramp(along, 0.5, 0.68)
+
+			lift := (iris.Y() - corners.Y()) / width
+			weights["eyeLookUp"+side.suffix] = ramp(lift, 0.03, 0.15)
+			weights["eyeLookDown"+side.suffix] = ramp(lift, -0.03, -0.12)
+		}
+
+		weights["browDown"+side.suffix] = ramp(at(side.browInner).Y()-inner.Y(), 0.19, 0.12)
+		weights["browOuterUp"+side.suffix] = ramp(at(side.browOuter).Y()-outer.Y(), 0.19, 0.28)
+
+		// Raised cheeks close in on the lower lid, and a sneer draws the
+		// nostril up towards the eye.
+		weights["cheekSquint"+side.suffix] = ramp(at(side.cheek).Distance(at(side.eyeLower)), 0.37, 0.30)
+		weights["noseSneer"+side.suffix] = ramp(at(side.nostril).Distance(inner), 0.43, 0.36)
+
+		// Smiling lifts the corner of the mouth above the lips' center,
+		// while frowning drops it below.
+		corner := at(side.mouthCorner)
+		lift := corner.Y() - lipCenter.Y()
+		smile := ramp(lift, 0.01, 0.09)
+		weights["mouthSmile"+side.suffix] = smile
+		weights["mouthFrown"+side.suffix] = ramp(lift, -0.02, -0.08)
+
+		// Stretching pulls the corner outwards without lifting it, and
+		// dimpling pulls it back into the cheek.
+		outward := (corner.X() - lipCenter.X()) * side.outward
+		weights["mouthStretch"+side.suffix] = ramp(outward, 0.29, 0.36) * (1 - smile)
+		weights["mouthDimple"+side.suffix] = ramp(corner.Z()-lipCenter.Z(), -0.12, -0.18) * (1 - smile)
+
+		weights["mouthUpperUp"+side.suffix] = ramp(at(side.nostril).Distance(at(side.upperLip)), 0.17, 0.08)
+		weights["mouthLowerDown"+side.suffix] = ramp(corner.Y()-at(side.lowerLip).Y(), 0.12, 0.22)
+
+		// Pressed lips are held together and flattened against each
+		// other.
+		thickness := at(side.upperLip).Distance(at(side.lowerLip))
+		weights["mouthPress"+side.suffix] = (1 - parted) * ramp(thickness, 0.16, 0.08)
+	}
+
+	// tongueOut is left at zero, as the face mesh doesn't track the tongue.
+	return weights, true
+}
@@ -0,0 +1,86 @@
+package face
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlendshapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape faceShape
+		want  map[string]float64
+	}{
+		{
+			name:  "neutral",
+			shape: neutralFace,
+			want:  map[string]float64{"eyeBlinkLeft": 0, "eyeBlinkRight": 0, "jawOpen": 0, "mouthClose": 0, "tongueOut": 0},
+		},
+		{
+			name:  "eyes closed",
+			shape: faceShape{eyeOpening: 0.015},
+			want:  map[string]float64{"eyeBlinkLeft": 1, "eyeBlinkRight": 1, "eyeWideLeft": 0, "jawOpen": 0},
+		},
+		{
+			name:  "eyes wide",
+			shape: faceShape{eyeOpening: 0.15},
+			want:  map[string]float64{"eyeBlinkLeft": 0, "eyeWideLeft": 1, "eyeWideRight": 1},
+		},
+		{
+			name:  "mouth open",
+			shape: faceShape{eyeOpening: 0.09, lipGap: 0.3},
+			want:  map[string]float64{"jawOpen": (0.3 - 0.02) / (0.40 - 0.02), "mouthClose": 0, "eyeBlinkLeft": 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			weights, ok := blendshapes(newFacePoints(placeFace(syntheticFace(tc.shape), 0, 0.5, 0.5, 0.1, 0.1)))
+			if !ok {
+				t.Fatalf("got no blendshapes")
+			}
+
+			for _, name := range BlendshapeNames {
+				if weight := weights[name]; weight < 0 || weight > 1 {
+					t.Fatalf("got %s of %g, want a weight from 0 to 1", name, weight)
+				}
+			}
+			for name, want := range tc.want {
+				if math.Abs(weights[name]-want) > 1e-9 {
+					t.Fatalf("got %s of %g, want %g", name, weights[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBlendshapesIgnoreAspectRatio(t *testing.T) {
+	face := syntheticFace(faceShape{eyeOpening: 0.05, lipGap: 0.2})
+	square, ok := blendshapes(newFacePoints(placeFace(face, 0, 0.5, 0.5, 0.1, 0.1)))
+	if !ok {
+		t.Fatalf("got no blendshapes from square video")
+	}
+
+	// The same face within 16:9 video, normalized to the video's width and
+	// height separately, is squeezed along X.
+	wide, ok := blendshapes(newFacePoints(placeFace(face, 0, 0.5, 0.5, 0.1*9/16, 0.1)))
+	if !ok {
+		t.Fatalf("got no blendshapes from wide video")
+	}
+
+	for _, name := range BlendshapeNames {
+		if math.Abs(square[name]-wide[name]) > 1e-9 {
+			t.Fatalf("got %s of %g in square video and %g in wide video", name, square[name], wide[name])
+		}
+	}
+}
+
+func TestBlendshapesMissingLandmarks(t *testing.T) {
+	marks := placeFace(syntheticFace(neutralFace), 0, 0.5, 0.5, 0.1, 0.1)
+	for _, missing := range []int{chin, noseBottom, mouthLeftCorner} {
+		without := append(append(marks[:0:0], marks[:missing]...), marks[missing+1:]...)
+		if _, ok := blendshapes(newFacePoints(without)); ok {
+			t.Fatalf("got blendshapes without landmark %d", missing)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package face
+
+import (
+	"sort"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/rap/format/collection/event"
+	"github.com/recolude/rap/format/collection/float"
+	"github.com/recolude/rap/format/metadata"
+)
+
+const (
+	// BlinkLeftEvent is the name of the event emitted the moment the left
+	// eye closes for a blink.
+	BlinkLeftEvent = "blink-left"
+
+	// BlinkRightEvent is the name of the event emitted the moment the right
+	// eye closes for a blink.
+	BlinkRightEvent = "blink-right"
+
+	// BlinkCollectionName is the name given to the event collection of
+	// blinks.
+	BlinkCollectionName = "Blinks"
+)
+
+// blinkEventNames are the names of each eye's blink events, indexed the same
+// as faceSides.
+var blinkEventNames = []string{BlinkLeftEvent, BlinkRightEvent}
+
+// BlinkDetection configures how blinks are found within the eye aspect ratio
+// of each eye. The eye aspect ratio is the height of the eye's opening over
+// its width, falling towards zero as the eye closes. It's measured within the
+// head's frame, so it reads the same no matter the video's aspect ratio.
+type BlinkDetection struct {
+	// CloseThreshold is the eye aspect ratio an open eye must fall below to
+	// be considered closed.
+	CloseThreshold float64
+
+	// OpenThreshold is the eye aspect ratio a closed eye must rise back above
+	// to be considered open again. Keeping it above CloseThreshold stops
+	// jitter around a single threshold from being taken for many blinks.
+	OpenThreshold float64
+
+	// MinDuration is the shortest time, in seconds, an eye must stay closed
+	// for its closure to count as a blink, discarding single frames of noise.
+	MinDuration float64
+}
+
+// NewBlinkDetection creates a blink detection with thresholds suited to a
+// typical adult face.
+func NewBlinkDetection() BlinkDetection {
+	return BlinkDetection{
+		CloseThreshold: 0.2,
+		OpenThreshold:  0.25,
+		MinDuration:    0.05,
+	}
+}
+
+// eyeAspectRatio measures how open the eye on the side of the face is as the
+// average distance between its upper and lower lids over the distance
+// between its corners, as described by Soukupová and Čech in "Real-Time Eye
+// Blink Detection using Facial Landmarks", measured within the head's frame.
+// ok is false if any of the eye's landmarks or the head's frame are missing.
+func eyeAspectRatio(fp facePoints, side faceSide) (ratio float64, ok bool) {
+	hf, ok := newHeadFrame(fp)
+	if !ok || !fp.has(side.eyeOuter, side.eyeInner, side.eyeUpperOuter, side.eyeUpperInner, side.eyeLowerOuter, side.eyeLowerInner) {
+		return 0, false
+	}
+	at := func(i int) vector.Vector3 {
+		return hf.measured(fp.pos[i])
+	}
+
+	width := at(side.eyeOuter).Distance(at(side.eyeInner))
+	if width == 0 {
+		return 0, false
+	}
+
+	outer := at(side.eyeUpperOuter).Distance(at(side.eyeLowerOuter))
+	inner := at(side.eyeUpperInner).Distance(at(side.eyeLowerInner))
+	return (outer + inner) / (2 * width), true
+}
+
+// blink is a single closing and reopening of an eye.
+type blink struct {
+	// closed is the first time the eye was seen closed.
+	closed float64
+
+	// opened is the first time the eye was seen open again.
+	opened float64
+}
+
+func (b blink) duration() float64 {
+	return b.opened - b.closed
+}
+
+// findBlinks looks through an eye's aspect ratio every frame it was measured
+// for blinks. A closure interrupted by the eye going unmeasured for longer
+// than maxGap, or still going at the end of the clip, can't be timed and is
+// dropped.
+func (bd BlinkDetection) findBlinks(ratios []float.Capture, maxGap float64) []blink {
+	blinks := make([]blink, 0)
+	closed := false
+	start := 0.
+	for i, ratio := range ratios {
+		if i > 0 && ratio.Time()-ratios[i-1].Time() > maxGap {
+			closed = false
+		}
+
+		if !closed {
+			if ratio.Value() < bd.CloseThreshold {
+				closed = true
+				start = ratio.Time()
+			}
+			continue
+		}
+
+		if ratio.Value() > bd.OpenThreshold {
+			closed = false
+			b := blink{closed: start, opened: ratio.Time()}
+			if b.duration() >= bd.MinDuration {
+				blinks = append(blinks, b)
+			}
+		}
+	}
+	return blinks
+}
+
+// blinkEvents builds an event for every blink of each eye, with the eye
+// aspect ratios indexed the same as faceSides. Each event is emitted the
+// moment the eye closed and carries how long it stayed closed in seconds.
+func (bd BlinkDetection) blinkEvents(ratios [][]float.Capture, maxGap float64) []event.Capture {
+	captures := make([]event.Capture, 0)
+	for i, name := range blinkEventNames {
+		for _, b := range bd.findBlinks(ratios[i], maxGap) {
+			captures = append(captures, event.NewCapture(b.closed, name, metadata.NewBlock(map[string]metadata.Property{
+				"duration": metadata.NewFloat32Property(float32(b.duration())),
+			})))
+		}
+	}
+
+	// Events are kept in the order they occurred, interleaving the eyes.
+	sort.SliceStable(captures, func(a, b int) bool {
+		return captures[a].Time() < captures[b].Time()
+	})
+	return captures
+}
@@ -0,0 +1,161 @@
+package face
+
+import (
+	"math"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format/collection/float"
+)
+
+func ratios(step float64, values ...float64) []float.Capture {
+	captures := make([]float.Capture, len(values))
+	for i, v := range values {
+		captures[i] = float.NewCapture(float64(i)*step, v)
+	}
+	return captures
+}
+
+func TestEyeAspectRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		shape   faceShape
+		scaleX  float64
+		want    float64
+		remove  int
+		missing bool
+	}{
+		{name: "open", shape: neutralFace, scaleX: 0.1, want: 0.3},
+		{name: "closed", shape: faceShape{eyeOpening: 0.015}, scaleX: 0.1, want: 0.05},
+		{name: "open in wide video", shape: neutralFace, scaleX: 0.1 * 9 / 16, want: 0.3},
+		{name: "closed in tall video", shape: faceShape{eyeOpening: 0.015}, scaleX: 0.1 * 16 / 9, want: 0.05},
+		{name: "lid missing", shape: neutralFace, scaleX: 0.1, remove: leftEyeUpperInner, missing: true},
+		{name: "head frame missing", shape: neutralFace, scaleX: 0.1, remove: foreheadTop, missing: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			marks := placeFace(syntheticFace(tc.shape), 0, 0.5, 0.5, tc.scaleX, 0.1)
+			if tc.missing {
+				marks = append(marks[:tc.remove:tc.remove], marks[tc.remove+1:]...)
+			}
+
+			got, ok := eyeAspectRatio(newFacePoints(marks), faceSides[0])
+			if ok == tc.missing {
+				t.Fatalf("got ok %v, want %v", ok, !tc.missing)
+			}
+			if math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("got %g, want %g", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindBlinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		ratios []float.Capture
+		want   []blink
+	}{
+		{
+			name:   "single blink",
+			ratios: ratios(0.05, 0.3, 0.1, 0.05, 0.3),
+			want:   []blink{{closed: 0.05, opened: 0.15000000000000002}},
+		},
+		{
+			name:   "jitter between the thresholds",
+			ratios: ratios(0.05, 0.3, 0.1, 0.22, 0.1, 0.22, 0.3),
+			want:   []blink{{closed: 0.05, opened: 0.25}},
+		},
+		{
+			name:   "too short",
+			ratios: ratios(0.01, 0.3, 0.1, 0.3),
+			want:   []blink{},
+		},
+		{
+			name:   "never reopened",
+			ratios: ratios(0.05, 0.3, 0.1, 0.1),
+			want:   []blink{},
+		},
+		{
+			name: "interrupted by a gap",
+			ratios: []float.Capture{
+				float.NewCapture(0, 0.3),
+				float.NewCapture(0.05, 0.1),
+				float.NewCapture(1, 0.3),
+			},
+			want: []blink{},
+		},
+		{
+			name:   "two blinks",
+			ratios: ratios(0.1, 0.3, 0.1, 0.3, 0.1, 0.3),
+			want:   []blink{{closed: 0.1, opened: 0.2}, {closed: 0.30000000000000004, opened: 0.4}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewBlinkDetection().findBlinks(tc.ratios, 0.15)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got blinks %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if math.Abs(got[i].closed-tc.want[i].closed) > 1e-9 || math.Abs(got[i].opened-tc.want[i].opened) > 1e-9 {
+					t.Fatalf("got blinks %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBlinkEvents(t *testing.T) {
+	events := NewBlinkDetection().blinkEvents([][]float.Capture{
+		ratios(0.1, 0.3, 0.3, 0.1, 0.3),
+		ratios(0.1, 0.3, 0.1, 0.3, 0.3),
+	}, 0.15)
+
+	want := []string{BlinkRightEvent, BlinkLeftEvent}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, name := range want {
+		if events[i].Name() != name || events[i].Metadata().Mapping()["duration"].String() != "0.100000" {
+			t.Fatalf("event %d: got %s lasting %v, want %s lasting 0.1s", i, events[i].Name(), events[i].Metadata().Mapping()["duration"], name)
+		}
+	}
+}
+
+func TestConvertBlinks(t *testing.T) {
+	open := syntheticFace(neutralFace)
+	closed := syntheticFace(faceShape{eyeOpening: 0.015})
+
+	converter := NewConverter(30)
+	blinks := NewBlinkDetection()
+	converter.Blinks = &blinks
+
+	frames := make([]landmark.Frame, 0)
+	for _, eyesClosed := range []bool{false, false, true, true, true, false} {
+		points := open
+		if eyesClosed {
+			points = closed
+		}
+
+		// Filmed in 16:9, which squeezes the face along X.
+		frames = append(frames, landmark.Frame{Landmarks: placeFace(points, 0, 0.5, 0.5, 0.1*9/16, 0.1)})
+	}
+
+	recording, err := converter.Convert(frames)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	count := 0
+	for _, collection := range recording.Recordings()[0].CaptureCollections() {
+		if collection.Name() == BlinkCollectionName {
+			count = collection.Length()
+		}
+	}
+	if count != 2 {
+		t.Fatalf("got %d blink events, want one per eye", count)
+	}
+}
@@ -1,128 +0,0 @@
-package main
-
-var landmarkContours = []Vector2Int{
-	NewVector2Int(270, 409),
-	NewVector2Int(176, 149),
-	NewVector2Int(37, 0),
-	NewVector2Int(84, 17),
-	NewVector2Int(318, 324),
-	NewVector2Int(293, 334),
-	NewVector2Int(386, 385),
-	NewVector2Int(7, 163),
-	NewVector2Int(33, 246),
-	NewVector2Int(17, 314),
-	NewVector2Int(374, 380),
-	NewVector2Int(251, 389),
-	NewVector2Int(390, 373),
-	NewVector2Int(267, 269),
-	NewVector2Int(295, 285),
-	NewVector2Int(389, 356),
-	NewVector2Int(173, 133),
-	NewVector2Int(33, 7),
-	NewVector2Int(377, 152),
-	NewVector2Int(158, 157),
-	NewVector2Int(405, 321),
-	NewVector2Int(54, 103),
-	NewVector2Int(263, 466),
-	NewVector2Int(324, 308),
-	NewVector2Int(67, 109),
-	NewVector2Int(409, 291),
-	NewVector2Int(157, 173),
-	NewVector2Int(454, 323),
-	NewVector2Int(388, 387),
-	NewVector2Int(78, 191),
-	NewVector2Int(148, 176),
-	NewVector2Int(311, 310),
-	NewVector2Int(39, 37),
-	NewVector2Int(249, 390),
-	NewVector2Int(144, 145),
-	NewVector2Int(402, 318),
-	NewVector2Int(80, 81),
-	NewVector2Int(310, 415),
-	NewVector2Int(153, 154),
-	NewVector2Int(384, 398),
-	NewVector2Int(397, 365),
-	NewVector2Int(234, 127),
-	NewVector2Int(103, 67),
-	NewVector2Int(282, 295),
-	NewVector2Int(338, 297),
-	NewVector2Int(378, 400),
-	NewVector2Int(127, 162),
-	NewVector2Int(321, 375),
-	NewVector2Int(375, 291),
-	NewVector2Int(317, 402),
-	NewVector2Int(81, 82),
-	NewVector2Int(154, 155),
-	NewVector2Int(91, 181),
-	NewVector2Int(334, 296),
-	NewVector2Int(297, 332),
-	NewVector2Int(269, 270),
-	NewVector2Int(150, 136),
-	NewVector2Int(109, 10),
-	NewVector2Int(356, 454),
-	NewVector2Int(58, 132),
-	NewVector2Int(312, 311),
-	NewVector2Int(152, 148),
-	NewVector2Int(415, 308),
-	NewVector2Int(161, 160),
-	NewVector2Int(296, 336),
-	NewVector2Int(65, 55),
-	NewVector2Int(61, 146),
-	NewVector2Int(78, 95),
-	NewVector2Int(380, 381),
-	NewVector2Int(398, 362),
-	NewVector2Int(361, 288),
-	NewVector2Int(246, 161),
-	NewVector2Int(162, 21),
-	NewVector2Int(0, 267),
-	NewVector2Int(82, 13),
-	NewVector2Int(132, 93),
-	NewVector2Int(314, 405),
-	NewVector2Int(10, 338),
-	NewVector2Int(178, 87),
-	NewVector2Int(387, 386),
-	NewVector2Int(381, 382),
-	NewVector2Int(70, 63),
-	NewVector2Int(61, 185),
-	NewVector2Int(14, 317),
-	NewVector2Int(105, 66),
-	NewVector2Int(300, 293),
-	NewVector2Int(382, 362),
-	NewVector2Int(88, 178),
-	NewVector2Int(185, 40),
-	NewVector2Int(46, 53),
-	NewVector2Int(284, 251),
-	NewVector2Int(400, 377),
-	NewVector2Int(136, 172),
-	NewVector2Int(323, 361),
-	NewVector2Int(13, 312),
-	NewVector2Int(21, 54),
-	NewVector2Int(172, 58),
-	NewVector2Int(373, 374),
-	NewVector2Int(163, 144),
-	NewVector2Int(276, 283),
-	NewVector2Int(53, 52),
-	NewVector2Int(365, 379),
-	NewVector2Int(379, 378),
-	NewVector2Int(146, 91),
-	NewVector2Int(263, 249),
-	NewVector2Int(283, 282),
-	NewVector2Int(87, 14),
-	NewVector2Int(145, 153),
-	NewVector2Int(155, 133),
-	NewVector2Int(93, 234),
-	NewVector2Int(66, 107),
-	NewVector2Int(95, 88),
-	NewVector2Int(159, 158),
-	NewVector2Int(52, 65),
-	NewVector2Int(332, 284),
-	NewVector2Int(40, 39),
-	NewVector2Int(191, 80),
-	NewVector2Int(63, 105),
-	NewVector2Int(181, 84),
-	NewVector2Int(466, 388),
-	NewVector2Int(149, 150),
-	NewVector2Int(288, 397),
-	NewVector2Int(160, 159),
-	NewVector2Int(385, 384),
-}
@@ -1,351 +1,210 @@
-package main
+// Package face converts mediapipe face mesh landmarks into recolude
+// recordings.
+package face
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"math"
-	"os"
+	"io"
 	"strconv"
+	"strings"
 
-	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/topology"
+	"github.com/recolude/pose-recording/tracking"
 	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/event"
+	"github.com/recolude/rap/format/collection/float"
 	"github.com/recolude/rap/format/collection/position"
-	"github.com/recolude/rap/format/encoding"
-	positionEncoder "github.com/recolude/rap/format/encoding/position"
-	rapio "github.com/recolude/rap/format/io"
 	"github.com/recolude/rap/format/metadata"
 )
 
-type Vector2Int struct {
-	X int
-	Y int
-}
-
-type AABB struct {
-	min    vector.Vector3
-	max    vector.Vector3
-	center *vector.Vector3
-}
-
-func NewAABB() *AABB {
-	return &AABB{
-		min: vector.NewVector3(math.Inf(1), math.Inf(1), math.Inf(1)),
-		max: vector.NewVector3(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
-	}
-}
-
-func (aabb *AABB) Center() vector.Vector3 {
-	if aabb.center == nil {
-		center := aabb.max.Add(aabb.min).DivByConstant(2)
-		aabb.center = &center
-	}
-	return *aabb.center
-}
-
-func (aabb *AABB) CenterPos(x, y, z float64) vector.Vector3 {
-	if aabb.center == nil {
-		center := aabb.max.Add(aabb.min).DivByConstant(2)
-		aabb.center = &center
-	}
-	return vector.NewVector3(x, y, z).Sub(*aabb.center)
-}
+// MediaPipe is the 478 landmark model reported by mediapipe face mesh with
+// refine_landmarks on, including the tesselated mesh and the irises.
+var MediaPipe = topology.MustBuiltin("mediapipe-face")
 
-func (aabb *AABB) Encompass(x, y, z float64) {
-	aabb.center = nil
-	aabb.min = vector.NewVector3(
-		math.Min(aabb.min.X(), x),
-		math.Min(aabb.min.Y(), y),
-		math.Min(aabb.min.Z(), z),
-	)
-
-	aabb.max = vector.NewVector3(
-		math.Max(aabb.max.X(), x),
-		math.Max(aabb.max.Y(), y),
-		math.Max(aabb.max.Z(), z),
-	)
+// Models are the built in models, by the short names the command line knows
+// them by.
+var Models = map[string]*topology.Topology{
+	"mediapipe": MediaPipe,
 }
 
-type Vertex struct {
-	Index int
-	Done  bool
+type runningData struct {
+	faces            []*faceTrack
+	frameRate        float64
+	maxMatchDistance float64
+	maxGap           float64
+	smoothing        filter.Factory
+
+	// aabb encompasses every landmark seen so far, used to center the faces
+	// once the entire clip has been seen.
+	aabb *landmark.AABB
+
+	// idPrefix is prepended to the ID of every landmark's recording.
+	idPrefix string
+
+	// model names, styles and connects the landmarks of each face, and
+	// describes the mesh they make up.
+	model *topology.Topology
+
+	// blendshapes is whether each face's blendshape coefficients are
+	// recorded.
+	blendshapes bool
+
+	// headPose is whether each face's head pose is recorded, and headLocal
+	// whether the face's landmarks are recorded relative to it.
+	headPose  bool
+	headLocal bool
+
+	// gaze is whether the direction each eye looks is recorded, and gazeRays
+	// how long of a line to draw out of each eye along it.
+	gaze     bool
+	gazeRays float64
+
+	// blinks, if set, is how blinks are found within each face's eye aspect
+	// ratios.
+	blinks *BlinkDetection
+
+	// mouth is whether how open and wide each face's mouth is, along with
+	// its viseme, is recorded.
+	mouth bool
 }
 
-func (v *Vertex) MarkDone() {
-	v.Done = true
+// landmarkID is the ID of the recording for a landmark of a face.
+func (rd *runningData) landmarkID(faceIndex, landmarkIndex int) string {
+	return rd.idPrefix + strconv.Itoa(landmarkIndex+(len(rd.model.Landmarks)*faceIndex))
 }
 
-func NewVertex(index int) *Vertex {
-	return &Vertex{Index: index, Done: false}
-}
+func (rd *runningData) faceRecording(faceIndex int, track *faceTrack) format.Recording {
+	captures := track.Centered(rd.aabb)
 
-func NewVector2Int(x, y int) Vector2Int {
-	return Vector2Int{X: x, Y: y}
-}
-
-func process(vertToProcess int, vertConnections map[int][]*Vertex, vertLUT map[int]*Vertex) [][]int {
-	connections := vertConnections[vertToProcess]
-
-	tris := make([][]int, 0)
-
-	vertLUT[vertToProcess].MarkDone()
-
-	for middleIndex := 0; middleIndex < len(connections)-1; middleIndex++ {
-
-		// Skip vertices fully explored
-		if vertLUT[connections[middleIndex].Index].Done {
-			continue
-		}
-
-		connectionsToMiddleVertex := vertConnections[connections[middleIndex].Index]
-
-		for _, potentialEndVertex := range connectionsToMiddleVertex {
-
-			if potentialEndVertex.Done {
-				continue
-			}
-
-			// Check if end vertex is contaied in original list.
-			for end := middleIndex + 1; end < len(connections); end++ {
-				// Their not connected
-				if potentialEndVertex != connections[end] {
-					continue
-				}
+	collections := make([]format.CaptureCollection, 0)
+	var poses []headPose
+	if rd.headPose || rd.headLocal || rd.gaze || rd.gazeRays > 0 {
+		poses = solveHeadPoses(track.Seen, captures)
+	}
+	if len(poses) > 0 && (rd.headPose || rd.headLocal) {
+		positions, rotations := headCollections(poses)
+		collections = append(collections, positions, rotations)
+	}
 
-				tris = append(tris, []int{vertToProcess, connections[middleIndex].Index, potentialEndVertex.Index})
-				break
+	var rays [][]position.Capture
+	if len(poses) > 0 && (rd.gaze || rd.gazeRays > 0) {
+		var directions [][]position.Capture
+		directions, rays = solveGaze(track.Seen, captures, poses, rd.gazeRays)
+		for i, side := range faceSides {
+			if len(directions[i]) > 0 {
+				collections = append(collections, position.NewCollection(side.suffix+" Gaze", directions[i]))
 			}
 		}
 	}
 
-	for _, conn := range connections {
-		if conn.Done {
-			continue
-		}
-		tris = append(tris, process(conn.Index, vertConnections, vertLUT)...)
+	if len(poses) > 0 && rd.headLocal {
+		captures = headLocal(captures, poses)
+		rays = headLocal(rays, poses)
 	}
 
-	return tris
-}
-
-func triNormal(tri []int, firstFrame []position.Capture) vector.Vector3 {
-	a := firstFrame[tri[0]].Position()
-	b := firstFrame[tri[1]].Position()
-	c := firstFrame[tri[2]].Position()
-	return b.Sub(a).Cross(c.Sub(a))
-}
-
-func clockwise(aabb *AABB, tri []int, firstFrame []position.Capture) bool {
-	a := firstFrame[tri[0]].Position()
-	b := firstFrame[tri[1]].Position()
-	c := firstFrame[tri[2]].Position()
-
-	// N = (B - A) x (C - A)
-	n := b.Sub(a).Cross(c.Sub(a))
-
-	// Winding when viewed from V
-	// w = N . (A - V)
-	return n.Dot(a.Sub(vector.Vector3Forward())) < 0
-}
+	childrenRecordings := make([]format.Recording, len(captures), len(captures)+len(rays))
 
-func rewindFaces(tris [][]int, firstFrame []position.Capture) {
-	trisProcessed := make(map[int]bool)
-	vertsToTris := make([][]int, 467+1)
-	for triIndex, tri := range tris {
-		vertsToTris[tri[0]] = append(vertsToTris[tri[0]], triIndex)
-		vertsToTris[tri[1]] = append(vertsToTris[tri[1]], triIndex)
-		vertsToTris[tri[2]] = append(vertsToTris[tri[2]], triIndex)
-		trisProcessed[triIndex] = false
+	for i, col := range captures {
+		childrenRecordings[i] = format.NewRecording(
+			rd.landmarkID(faceIndex, i),
+			rd.model.LandmarkName(i),
+			[]format.CaptureCollection{
+				position.NewCollection(landmark.PositionCollectionName, col),
+			},
+			nil,
+			rd.model.LandmarkMetadata(i),
+			nil,
+			nil,
+		)
 	}
 
-	triToNeighbors := make([]map[int]bool, len(tris))
-	for triIndex, tri := range tris {
-		triToNeighbors[triIndex] = make(map[int]bool)
-		for _, vertsTri := range vertsToTris[tri[0]] {
-			triToNeighbors[triIndex][vertsTri] = false
-		}
-		for _, vertsTri := range vertsToTris[tri[1]] {
-			triToNeighbors[triIndex][vertsTri] = false
-		}
-		for _, vertsTri := range vertsToTris[tri[2]] {
-			triToNeighbors[triIndex][vertsTri] = false
-		}
+	landmarkID := func(landmark int) string {
+		return rd.landmarkID(faceIndex, landmark)
 	}
 
-	triNormals := make([]vector.Vector3, len(tris))
-	for triIndex, tri := range tris {
-		triNormals[triIndex] = triNormal(tri, firstFrame)
+	metadataMeshes := make([]metadata.Block, 0, 1)
+	if track.complete(rd.model.MeshVertices()) {
+		metadataMeshes = append(metadataMeshes, rd.model.Mesh(landmarkID))
 	}
 
-	queue := make([]int, 0)
-
-	// Push to the queue
-	queue = append(queue, 0)
+	metadataLines := rd.model.Lines(len(captures), landmarkID)
 
-	var triIndex int
-	for len(queue) > 0 {
-		triIndex, queue = queue[0], queue[1:]
-
-		// Skip if we've already been processed in the past
-		if trisProcessed[triIndex] {
+	for i, ray := range rays {
+		if len(ray) == 0 {
 			continue
 		}
-		trisProcessed[triIndex] = true
-
-		for neighborIndex := range triToNeighbors[triIndex] {
-			if trisProcessed[neighborIndex] {
-				continue
-			}
-			queue = append(queue, neighborIndex)
-
-			otherSide := triNormal([]int{tris[neighborIndex][2], tris[neighborIndex][1], tris[neighborIndex][0]}, firstFrame)
-			if triNormals[triIndex].Dot(triNormals[neighborIndex]) < triNormals[triIndex].Dot(otherSide) {
-				tris[neighborIndex][0], tris[neighborIndex][2] = tris[neighborIndex][2], tris[neighborIndex][0]
-				triNormals[neighborIndex] = otherSide
-			}
-		}
+		side := faceSides[i]
+		rayID := fmt.Sprintf("%sface-%d-gaze-%s", rd.idPrefix, faceIndex, strings.ToLower(side.suffix))
+		childrenRecordings = append(childrenRecordings, format.NewRecording(
+			rayID,
+			side.suffix+" Gaze",
+			[]format.CaptureCollection{
+				position.NewCollection(landmark.PositionCollectionName, ray),
+			},
+			nil,
+			metadata.NewBlock(map[string]metadata.Property{
+				"recolude-geom": metadata.NewStringProperty("none"),
+			}),
+			nil,
+			nil,
+		))
+		metadataLines = append(metadataLines, metadata.NewBlock(map[string]metadata.Property{
+			"starting-object-id": metadata.NewStringProperty(landmarkID(side.iris)),
+			"ending-object-id":   metadata.NewStringProperty(rayID),
+			"color":              metadata.NewStringProperty(gazeColor),
+			"width":              metadata.NewFloat32Property(0.0025),
+		}))
 	}
-}
-
-// tesselate is a pretty poor function I'm writing drunk just to get this done.
-// There are probably most definantly better ways to do this.
-func tesselate(aabb *AABB, firstFrame []position.Capture) [][]int {
-	numVerts := 467 + 1
 
-	vertLUT := make(map[int]*Vertex)
-	for i := 0; i < numVerts; i++ {
-		vertLUT[i] = NewVertex(i)
+	if gaps := tracking.FindGaps(track.Seen, rd.maxGap); len(gaps) > 0 {
+		collections = append(collections, event.NewCollection(tracking.CollectionName, tracking.Events(gaps)))
 	}
-
-	vertConnections := make(map[int][]*Vertex)
-	processed := make(map[string]bool)
-	for _, line := range lineSegments {
-
-		// Avoid processesing duplicate line segments
-		id := fmt.Sprintf("%d-%d", line.X, line.Y)
-		if line.Y < line.X {
-			id = fmt.Sprintf("%d-%d", line.Y, line.X)
+	for i, captures := range track.blendshapes {
+		if len(captures) > 0 {
+			collections = append(collections, float.NewCollection(BlendshapeNames[i], captures))
 		}
-
-		if _, ok := processed[id]; ok {
-			continue
-		}
-		processed[id] = true
-
-		if val, ok := vertConnections[line.X]; ok {
-			vertConnections[line.X] = append(val, vertLUT[line.Y])
-		} else {
-			vertConnections[line.X] = []*Vertex{vertLUT[line.Y]}
+	}
+	if rd.blinks != nil {
+		for i, side := range faceSides {
+			if len(track.eyeAspectRatios[i]) > 0 {
+				collections = append(collections, float.NewCollection(side.suffix+" Eye Aspect Ratio", track.eyeAspectRatios[i]))
+			}
 		}
-
-		if val, ok := vertConnections[line.Y]; ok {
-			vertConnections[line.Y] = append(val, vertLUT[line.X])
-		} else {
-			vertConnections[line.Y] = []*Vertex{vertLUT[line.X]}
+		if blinks := rd.blinks.blinkEvents(track.eyeAspectRatios, rd.maxGap); len(blinks) > 0 {
+			collections = append(collections, event.NewCollection(BlinkCollectionName, blinks))
 		}
 	}
-
-	tris := make([][]int, 0)
-	tris = append(tris, process(0, vertConnections, vertLUT)...)
-
-	// Get the tris facing the generally correct direction
-	for _, tri := range tris {
-		if !clockwise(aabb, tri, firstFrame) {
-			tri[0], tri[2] = tri[2], tri[0]
+	if rd.mouth {
+		if shapes := mouthShapes(track.Seen, track.Captures); len(shapes) > 0 {
+			open, width, visemes := mouthCollections(shapes)
+			collections = append(collections, open, width, visemes)
 		}
 	}
 
-	rewindFaces(tris, firstFrame)
-
-	return tris
-}
+	faceMetadata := metadata.EmptyBlock()
+	faceMetadata.Mapping()["recolude-lines"] = metadata.NewMetadataArrayProperty(metadataLines)
+	faceMetadata.Mapping()["recolude-meshes"] = metadata.NewMetadataArrayProperty(metadataMeshes)
 
-type RunningData struct {
-	captures [][]position.Capture
-}
-
-func triIndicesForFace(tris [][]int, faceIndex int) []string {
-	offset := 478 * faceIndex
-	allTris := make([]string, len(tris)*3)
-	for i, tri := range tris {
-		offsetI := (i * 3)
-		allTris[offsetI] = strconv.Itoa(tri[0] + offset)
-		allTris[offsetI+1] = strconv.Itoa(tri[1] + offset)
-		allTris[offsetI+2] = strconv.Itoa(tri[2] + offset)
-	}
-	return allTris
+	return format.NewRecording(
+		fmt.Sprintf("face-%d", faceIndex),
+		fmt.Sprintf("Face %d", faceIndex),
+		collections,
+		childrenRecordings,
+		faceMetadata,
+		nil,
+		nil,
+	)
 }
 
-func (rd *RunningData) toRecording(aabb *AABB) format.Recording {
-	childrenRecordings := make([]format.Recording, len(rd.captures))
-
-	childStyling := metadata.EmptyBlock()
-	// childStyling.Mapping()["recolude-scale"] = metadata.NewStringProperty("0.015, 0.015, 0.015")
-	// childStyling.Mapping()["recolude-color"] = metadata.NewStringProperty("#00FFFF")
-	childStyling.Mapping()["recolude-geom"] = metadata.NewStringProperty("none")
-
-	eyeStyling := metadata.EmptyBlock()
-	eyeStyling.Mapping()["recolude-scale"] = metadata.NewStringProperty("0.015, 0.015, 0.015")
-	eyeStyling.Mapping()["recolude-color"] = metadata.NewStringProperty("#00FFFF")
-	eyeStyling.Mapping()["recolude-geom"] = metadata.NewStringProperty("sphere")
-	eyeStyling.Mapping()["body-part"] = metadata.NewStringProperty("pupil")
-
-	for i, col := range rd.captures {
-		block := childStyling
-		if i%478 == 473 || i%478 == 468 {
-			block = eyeStyling
-		}
-
-		childrenRecordings[i] = format.NewRecording(
-			strconv.Itoa(i),
-			strconv.Itoa(i),
-			[]format.CaptureCollection{
-				position.NewCollection("Position", col),
-			},
-			nil,
-			block,
-			nil,
-			nil,
-		)
-	}
-
-	tris := tesselate(aabb, rd.FirstFrame())
-	metadataMeshes := make([]metadata.Block, len(rd.captures)/478)
-	for faceIndex := 0; faceIndex < len(rd.captures)/478; faceIndex++ {
-		metadataMeshes[faceIndex] = metadata.NewBlock(map[string]metadata.Property{
-			"type": metadata.NewStringProperty("subject-as-vertices"),
-			"tris": metadata.NewStringArrayProperty(triIndicesForFace(tris, faceIndex)),
-		})
-	}
-
-	metadataLines := make([]metadata.Block, 0, len(landmarkContours)+len(landmarkIrises))
-
-	// Uncomment if you want all the lines around the face
-	// for _, link := range landmarkContours {
-	// 	mapping := map[string]metadata.Property{
-	// 		"starting-object-id": metadata.NewStringProperty(strconv.Itoa(int(link.X))),
-	// 		"ending-object-id":   metadata.NewStringProperty(strconv.Itoa(int(link.Y))),
-	// 		"color":              metadata.NewStringProperty("#00FF00"),
-	// 		"width":              metadata.NewFloat32Property(0.01),
-	// 	}
-	// 	metadataLines = append(metadataLines, metadata.NewBlock(mapping))
-	// }
-
-	for faceIndex := 0; faceIndex < len(rd.captures)/478; faceIndex++ {
-		for _, link := range landmarkIrises {
-			mapping := map[string]metadata.Property{
-				"starting-object-id": metadata.NewStringProperty(strconv.Itoa(int(link.X) + (faceIndex * 478))),
-				"ending-object-id":   metadata.NewStringProperty(strconv.Itoa(int(link.Y) + (faceIndex * 478))),
-				"color":              metadata.NewStringProperty("#00FFFF"),
-				"width":              metadata.NewFloat32Property(0.0025),
-			}
-			metadataLines = append(metadataLines, metadata.NewBlock(mapping))
-		}
+func (rd *runningData) toRecording() format.Recording {
+	faceRecordings := make([]format.Recording, len(rd.faces))
+	for faceIndex, track := range rd.faces {
+		faceRecordings[faceIndex] = rd.faceRecording(faceIndex, track)
 	}
 
 	recordingMetadata := metadata.EmptyBlock()
-	recordingMetadata.Mapping()["recolude-lines"] = metadata.NewMetadataArrayProperty(metadataLines)
-	recordingMetadata.Mapping()["recolude-meshes"] = metadata.NewMetadataArrayProperty(metadataMeshes)
 	recordingMetadata.Mapping()["recolude-sun-position"] = metadata.NewVector3Property(0, 200, -100)
 	recordingMetadata.Mapping()["recolude-grid"] = metadata.NewStringProperty("false")
 	recordingMetadata.Mapping()["recolude-skybox"] = metadata.NewStringProperty("webplayer-assets/examples/landmarks/nightskycolor.png")
@@ -354,85 +213,208 @@ func (rd *RunningData) toRecording(aabb *AABB) format.Recording {
 		"face",
 		"Face Capture Demo",
 		[]format.CaptureCollection{},
-		childrenRecordings,
+		faceRecordings,
 		recordingMetadata,
 		nil,
 		nil,
 	)
 }
 
-func (rd *RunningData) process(curTime float64, frame []LandMark, aabb *AABB) {
-	for i, landmark := range frame {
-		if len(rd.captures) < i+1 {
-			rd.captures = append(rd.captures, make([]position.Capture, 0))
+func (rd *runningData) process(curTime float64, frame []landmark.LandMark) error {
+	if err := tracking.CheckIDs(frame, rd.model); err != nil {
+		return err
+	}
+
+	for _, mark := range frame {
+		rd.aabb.Encompass(mark.X, mark.Y, mark.Z)
+	}
+
+	faces := tracking.GroupBy(frame, func(mark landmark.LandMark) int {
+		return mark.FaceID
+	})
+	for i, track := range matchFaces(rd.faces, faces, rd.maxMatchDistance) {
+		if track == nil {
+			track = newFaceTrack(rd.smoothing, len(rd.model.Landmarks), rd.blendshapes, rd.blinks != nil)
+			rd.faces = append(rd.faces, track)
 		}
-		centered := aabb.CenterPos(landmark.X, landmark.Y, landmark.Z)
-		rd.captures[i] = append(rd.captures[i], position.NewCapture(curTime, (centered.X()*2), (-centered.Y()*2), centered.Z()*2))
+		track.add(curTime, faces[i], rd.maxGap)
 	}
+	return nil
 }
 
-func (rd *RunningData) FirstFrame() []position.Capture {
-	firstFrame := make([]position.Capture, len(rd.captures))
-	for i, collection := range rd.captures {
-		firstFrame[i] = collection[0]
+// Converter builds recordings out of frames of face mesh landmarks.
+type Converter struct {
+	// FrameRate is the rate the frames were captured at, used to determine
+	// when a frame occurred if it carries no timestamp of its own.
+	FrameRate float64
+
+	// MaxMatchDistance is how far apart (in the detector's normalized
+	// coordinates) a face can be from where a face was last seen and still be
+	// considered the same face.
+	MaxMatchDistance float64
+
+	// MaxGap is the longest time, in seconds, a face can go undetected before
+	// it's considered lost. Zero derives the gap from the frame rate.
+	MaxGap float64
+
+	// Smoothing builds the filters used to smooth each face's landmarks
+	// before they're captured. Nil leaves the landmarks untouched.
+	Smoothing filter.Factory
+
+	// Bounds, if set, is grown to encompass every landmark and used to center
+	// the faces in place of bounds of the converter's own. Sharing bounds
+	// between converters places their recordings in the same space.
+	Bounds *landmark.AABB
+
+	// IDPrefix is prepended to the ID of every landmark's recording, keeping
+	// IDs unique when the recording sits alongside others.
+	IDPrefix string
+
+	// Topology names, styles and connects the landmarks of each face, and
+	// describes the mesh they make up. Left nil, landmarks are assumed to
+	// come from mediapipe. Frames holding a landmark ID the topology doesn't
+	// define are rejected.
+	Topology *topology.Topology
+
+	// Blendshapes, if set, estimates ARKit's 52 blendshape coefficients from
+	// each face's mesh every frame, recorded as a float collection per
+	// blendshape on the face's recording. Requires mediapipe's face mesh.
+	Blendshapes bool
+
+	// HeadPose, if set, rigidly fits each face's head to every frame,
+	// recording where the head is and how it's turned as Position and
+	// Rotation collections on the face's recording.
+	HeadPose bool
+
+	// HeadLocal, if set, records each face's landmarks relative to its head
+	// pose, leaving only the movement of the face's expressions. Implies
+	// HeadPose.
+	HeadLocal bool
+
+	// Gaze, if set, estimates the direction each eye looks every frame from
+	// where its iris sits between its corners, turned by the head's pose.
+	// Directions are recorded as unit vectors within "Left Gaze" and "Right
+	// Gaze" position collections on the face's recording. Requires the iris
+	// landmarks.
+	Gaze bool
+
+	// GazeRays, if greater than zero, draws a line this long out of each eye
+	// along its gaze. Implies Gaze.
+	GazeRays float64
+
+	// Blinks, if set, measures each eye's aspect ratio every frame, recorded
+	// within "Left Eye Aspect Ratio" and "Right Eye Aspect Ratio" float
+	// collections, and finds the blinks within them, recorded as blink-left
+	// and blink-right events on the face's recording. Requires mediapipe's
+	// face mesh.
+	Blinks *BlinkDetection
+
+	// Mouth, if set, measures each face's mouth every frame, recording the
+	// gap between its lips relative to its width within a "Mouth Open" float
+	// collection, its width relative to the distance between the eyes within
+	// a "Mouth Width" float collection, and the viseme it makes within a
+	// "Viseme" enum collection, all on the face's recording. Requires
+	// mediapipe's face mesh.
+	Mouth bool
+}
+
+// NewConverter creates a converter for frames captured at the provided frame
+// rate.
+func NewConverter(frameRate float64) Converter {
+	return Converter{
+		FrameRate:        frameRate,
+		MaxMatchDistance: 0.2,
+		Topology:         MediaPipe,
 	}
-	return firstFrame
 }
 
-type LandMark struct {
-	X  float64 `json:"x"`
-	Y  float64 `json:"y"`
-	Z  float64 `json:"z"`
-	ID int     `json:"id"`
+func (c Converter) newRunningData() *runningData {
+	aabb := c.Bounds
+	if aabb == nil {
+		aabb = landmark.NewAABB()
+	}
+	model := c.Topology
+	if model == nil {
+		model = MediaPipe
+	}
+	return &runningData{
+		faces:            make([]*faceTrack, 0),
+		frameRate:        c.FrameRate,
+		maxMatchDistance: c.MaxMatchDistance,
+		maxGap:           tracking.ResolveMaxGap(c.MaxGap, c.FrameRate),
+		smoothing:        c.Smoothing,
+		aabb:             aabb,
+		idPrefix:         c.IDPrefix,
+		model:            model,
+		blendshapes:      c.Blendshapes,
+		headPose:         c.HeadPose,
+		headLocal:        c.HeadLocal,
+		gaze:             c.Gaze,
+		gazeRays:         c.GazeRays,
+		blinks:           c.Blinks,
+		mouth:            c.Mouth,
+	}
 }
 
-func (lm LandMark) String() string {
-	return fmt.Sprintf("%d: %f, %f, %f", lm.ID, lm.X, lm.Y, lm.Z)
+// Convert builds a single recording containing every face found within the
+// frames. Each face gets its own child recording, which in turn contains a
+// child recording per landmark. Any stretch of time a face went undetected is
+// marked with tracking events on the face's recording.
+func (c Converter) Convert(frames []landmark.Frame) (format.Recording, error) {
+	return c.Stream(landmark.NewSliceReader(frames))
 }
 
-func check(err error) {
-	if err != nil {
-		panic(err)
+// Stream builds the same recording as Convert, but reads frames one at a
+// time, never holding onto more than a single frame.
+func (c Converter) Stream(frames landmark.FrameReader) (format.Recording, error) {
+	builder := c.NewBuilder()
+	if err := landmark.Feed(frames, c.FrameRate, builder.Add); err != nil {
+		return nil, err
 	}
+	return builder.Recording(), nil
 }
 
-func main() {
-	jsonFile, err := os.Open("face.json")
-	check(err)
-	defer jsonFile.Close()
+// Builder builds a recording one frame at a time, for callers feeding the
+// converter landmarks from a source other than a FrameReader.
+type Builder struct {
+	rd *runningData
+}
 
-	byteValue, err := ioutil.ReadAll(jsonFile)
-	check(err)
+// NewBuilder creates a builder that produces the same recording Convert
+// would for the frames added to it.
+func (c Converter) NewBuilder() *Builder {
+	return &Builder{rd: c.newRunningData()}
+}
 
-	var frames [][]LandMark
-	check(json.Unmarshal(byteValue, &frames))
+// Add records the landmarks detected within a frame that occurred at the
+// provided time, in seconds.
+func (b *Builder) Add(curTime float64, marks []landmark.LandMark) error {
+	return b.rd.process(curTime, marks)
+}
 
-	// Calc AABB to shift to center
-	aabb := NewAABB()
-	for _, frame := range frames {
-		for _, mark := range frame {
-			aabb.Encompass(mark.X, mark.Y, mark.Z)
-		}
-	}
+// Recording builds the recording out of every frame added so far.
+func (b *Builder) Recording() format.Recording {
+	return b.rd.toRecording()
+}
 
-	rd := &RunningData{
-		captures: make([][]position.Capture, 0),
-	}
-	curTime := 0.0
+// WriteGLB writes every face with a complete mesh as a binary glTF. Each face
+// is built from the first frame it was seen in, with a morph target per frame
+// it was seen in, animated so playback blends from one frame to the next. The
+// frames are split across several meshes, each shown in turn, keeping the
+// morph targets of each within what engines will load.
+func (b *Builder) WriteGLB(out io.Writer) error {
+	return b.rd.writeGLB(out)
+}
 
-	for _, frame := range frames {
-		rd.process(curTime, frame, aabb)
-		curTime += 1.0 / 30.0
-	}
+// Faces is how many faces have been seen so far.
+func (b *Builder) Faces() int {
+	return len(b.rd.faces)
+}
 
-	f, _ := os.Create("face tracking.rap")
-	recordingWriter := rapio.NewWriter(
-		[]encoding.Encoder{
-			positionEncoder.NewEncoder(positionEncoder.Oct24),
-		},
-		true,
-		f,
-		rapio.BST16,
-	)
-	recordingWriter.Write(rd.toRecording(aabb))
+// WriteLipSync writes the visemes of a single face's mouth, by the index of
+// the face, as the JSON Rhubarb Lip Sync writes. Consecutive frames with the
+// same viseme are merged into a single cue, and the mouth is taken to be at
+// rest whenever it went unseen.
+func (b *Builder) WriteLipSync(out io.Writer, faceIndex int) error {
+	return b.rd.writeLipSync(out, faceIndex)
 }
@@ -54,8 +54,6 @@ def process_frames(frames, out_frame_path, out_path):
                     })
                     i += 1
 
-                data_out.append(entry)
-
                 mp_drawing.draw_landmarks(
                     image=annotated_image,
                     landmark_list=face_landmarks,
@@ -82,6 +80,11 @@ def process_frames(frames, out_frame_path, out_path):
 
                 face_index += 1
 
+            data_out.append({
+                "frame": idx,
+                "landmarks": entry,
+            })
+
             out_img_path = os.path.join(
                 out_frame_path, f"frame_{str(idx + 1).zfill(4)}.png")
             cv2.imwrite(out_img_path, annotated_image)
@@ -0,0 +1,180 @@
+package face
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/landmark"
+)
+
+// faceShape is the expression a synthetic face is made with.
+type faceShape struct {
+	// eyeOpening is the gap between the lids of each eye.
+	eyeOpening float64
+
+	// lipGap is the gap between the lips.
+	lipGap float64
+}
+
+var neutralFace = faceShape{eyeOpening: 0.09}
+
+// syntheticFace places every landmark of mediapipe's face mesh for an
+// upright face looking straight at the camera, in units of the distance
+// between the outer corners of its eyes, with y pointing down as it does in
+// the detector's coordinates. The landmarks expressions are measured from are
+// placed where they sit on a typical face, with the height from beneath the
+// nose to the top of the forehead being typicalHeadHeight, and the rest are
+// scattered across the face.
+func syntheticFace(shape faceShape) []vector.Vector3 {
+	points := make([]vector.Vector3, len(MediaPipe.Landmarks))
+	for i := range points {
+		r := math.Sqrt(float64(i) / float64(len(points)))
+		points[i] = vector.NewVector3(0.6*r*math.Cos(float64(i)*2.4), 0.1+0.9*r*math.Sin(float64(i)*2.4), 0.2*math.Sin(float64(i)*1.3))
+	}
+
+	lid := shape.eyeOpening / 2
+	named := map[int][3]float64{
+		foreheadTop: {0, -0.8, 0},
+		noseTip:     {0, 0.3, -0.15},
+		noseBottom:  {0, 0.4, 0},
+		chin:        {0, 1, 0},
+
+		rightEyeOuter: {-0.5, 0, 0}, rightEyeInner: {-0.2, 0, 0},
+		leftEyeOuter: {0.5, 0, 0}, leftEyeInner: {0.2, 0, 0},
+		rightEyeUpper: {-0.35, -lid, 0}, rightEyeLower: {-0.35, lid, 0},
+		leftEyeUpper: {0.35, -lid, 0}, leftEyeLower: {0.35, lid, 0},
+		rightEyeUpperOuter: {-0.4, -lid, 0}, rightEyeLowerOuter: {-0.4, lid, 0},
+		rightEyeUpperInner: {-0.3, -lid, 0}, rightEyeLowerInner: {-0.3, lid, 0},
+		leftEyeUpperOuter: {0.4, -lid, 0}, leftEyeLowerOuter: {0.4, lid, 0},
+		leftEyeUpperInner: {0.3, -lid, 0}, leftEyeLowerInner: {0.3, lid, 0},
+		rightIrisCenter: {-0.35, 0, -0.02}, leftIrisCenter: {0.35, 0, -0.02},
+
+		rightBrowInner: {-0.25, -0.22, 0}, rightBrowOuter: {-0.45, -0.22, 0},
+		leftBrowInner: {0.25, -0.22, 0}, leftBrowOuter: {0.45, -0.22, 0},
+		rightNostril: {-0.1, 0.38, 0}, leftNostril: {0.1, 0.38, 0},
+		rightCheek: {-0.4, 0.35, 0}, leftCheek: {0.4, 0.35, 0},
+
+		mouthRightCorner: {-0.25, 0.65, 0}, mouthLeftCorner: {0.25, 0.65, 0},
+		upperLipTop: {0, 0.58, 0}, upperLipInner: {0, 0.65, 0},
+		lowerLipInner: {0, 0.65 + shape.lipGap, 0}, lowerLipBottom: {0, 0.72 + shape.lipGap, 0},
+		upperLipRight: {-0.12, 0.6, 0}, upperLipLeft: {0.12, 0.6, 0},
+		lowerLipRight: {-0.12, 0.7 + shape.lipGap, 0}, lowerLipLeft: {0.12, 0.7 + shape.lipGap, 0},
+	}
+	for i, p := range named {
+		points[i] = vector.NewVector3(p[0], p[1], p[2])
+	}
+	return points
+}
+
+// placeFace moves the face's points into the detector's coordinates, centered
+// at x and y and scaled along each axis, as the landmarks of the face with
+// the ID.
+func placeFace(points []vector.Vector3, faceID int, x, y, scaleX, scaleY float64) []landmark.LandMark {
+	marks := make([]landmark.LandMark, len(points))
+	for i, p := range points {
+		marks[i] = landmark.LandMark{
+			ID:     i,
+			FaceID: faceID,
+			X:      x + (p.X() * scaleX),
+			Y:      y + (p.Y() * scaleY),
+			Z:      p.Z() * scaleX,
+		}
+	}
+	return marks
+}
+
+func TestConvertTracksFaces(t *testing.T) {
+	face := syntheticFace(neutralFace)
+	at := func(faceID int, x float64) []landmark.LandMark {
+		return placeFace(face, faceID, x, 0.5, 0.1, 0.1)
+	}
+	frame := func(faces ...[]landmark.LandMark) landmark.Frame {
+		marks := make([]landmark.LandMark, 0)
+		for _, f := range faces {
+			marks = append(marks, f...)
+		}
+		return landmark.Frame{Landmarks: marks}
+	}
+
+	tests := []struct {
+		name   string
+		frames []landmark.Frame
+		want   []int
+	}{
+		{
+			name:   "single face",
+			frames: []landmark.Frame{frame(at(0, 0.5)), frame(at(0, 0.52))},
+			want:   []int{2},
+		},
+		{
+			name: "face IDs swapped between frames",
+			frames: []landmark.Frame{
+				frame(at(0, 0.2), at(1, 0.8)),
+				frame(at(0, 0.8), at(1, 0.2)),
+				frame(at(1, 0.21)),
+			},
+			want: []int{3, 2},
+		},
+		{
+			name: "new face far from the others",
+			frames: []landmark.Frame{
+				frame(at(0, 0.2)),
+				frame(at(0, 0.8)),
+			},
+			want: []int{1, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recording, err := NewConverter(30).Convert(tc.frames)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			faces := recording.Recordings()
+			if len(faces) != len(tc.want) {
+				t.Fatalf("got %d faces, want %d", len(faces), len(tc.want))
+			}
+			for i, want := range tc.want {
+				positions := landmark.Positions(faces[i].Recordings()[noseTip])
+				if len(positions) != want {
+					t.Fatalf("face %d: got %d captures, want %d", i, len(positions), want)
+				}
+
+				// Each face stays within a few hundredths of where it was
+				// first seen.
+				for _, capture := range positions {
+					if math.Abs(capture.Position().X()-positions[0].Position().X()) > 0.1 {
+						t.Fatalf("face %d: got captures %v, want a face that stayed put", i, positions)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConvertRejectsUnknownLandmarks(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int
+	}{
+		{"negative", -1},
+		{"past the mesh", len(MediaPipe.Landmarks)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := NewConverter(30).NewBuilder()
+			err := builder.Add(0, []landmark.LandMark{{ID: 0}, {ID: tc.id}})
+			if err == nil || !strings.HasPrefix(err.Error(), "landmark ID") {
+				t.Fatalf("got error %v, want one about the landmark ID", err)
+			}
+			if builder.Faces() != 0 {
+				t.Fatalf("got %d faces from the rejected frame, want none", builder.Faces())
+			}
+		})
+	}
+}
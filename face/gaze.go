@@ -0,0 +1,74 @@
+package face
+
+import (
+	"math"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// eyeballRadius is the radius of the eyeball relative to the distance between
+// the corners of the eye.
+const eyeballRadius = 0.4
+
+// gazeColor is the color rays are drawn out of the eyes with.
+const gazeColor = "#FF00FF"
+
+// gazeDirection is the direction the eye on the side of the face looks in,
+// within the recording's space. The eyeball is taken to be a sphere centered
+// behind the middle of the eye's corners, with the iris on its surface, so
+// the further the iris sits from the middle of the corners the further the
+// eye is turned. ok is false if the eye's corners or iris are missing.
+func gazeDirection(pose headPose, fp facePoints, side faceSide) (direction vector.Vector3, ok bool) {
+	if !fp.has(side.eyeOuter, side.eyeInner, side.iris) {
+		return vector.Vector3Zero(), false
+	}
+
+	// Measured within the head's space, where the face looks down -Z.
+	outer := pose.local(fp.pos[side.eyeOuter])
+	inner := pose.local(fp.pos[side.eyeInner])
+	iris := pose.local(fp.pos[side.iris])
+
+	radius := outer.Distance(inner) * eyeballRadius
+	if radius == 0 {
+		return vector.Vector3Zero(), false
+	}
+	offset := iris.Sub(outer.Add(inner).DivByConstant(2))
+
+	dx, dy := offset.X(), offset.Y()
+	depth := math.Sqrt(math.Max(0, (radius*radius)-(dx*dx)-(dy*dy)))
+	return pose.rotation.apply(vector.NewVector3(dx, dy, -depth).Normalized()), true
+}
+
+// solveGaze finds the direction each eye looked in every frame its iris and
+// the head's pose are known, indexed the same as faceSides. Directions are
+// unit vectors within the recording's space. If rayLength is greater than
+// zero, rays are points rayLength along each eye's gaze from its iris, for
+// drawing lines out of the eyes.
+func solveGaze(seen []float64, captures [][]position.Capture, poses []headPose, rayLength float64) (directions, rays [][]position.Capture) {
+	directions = make([][]position.Capture, len(faceSides))
+	rays = make([][]position.Capture, len(faceSides))
+
+	next := 0
+	eachFrame(seen, captures, func(t float64, fp facePoints) {
+		if next >= len(poses) || poses[next].time != t {
+			return
+		}
+		pose := poses[next]
+		next++
+
+		for i, side := range faceSides {
+			direction, ok := gazeDirection(pose, fp, side)
+			if !ok {
+				continue
+			}
+			directions[i] = append(directions[i], position.NewCapture(t, direction.X(), direction.Y(), direction.Z()))
+
+			if rayLength > 0 {
+				end := fp.pos[side.iris].Add(direction.MultByConstant(rayLength))
+				rays[i] = append(rays[i], position.NewCapture(t, end.X(), end.Y(), end.Z()))
+			}
+		}
+	})
+	return directions, rays
+}
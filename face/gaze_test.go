@@ -0,0 +1,58 @@
+package face
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/vector"
+)
+
+func TestGazeDirection(t *testing.T) {
+	// Each eye is 0.3 wide, giving an eyeball of radius 0.12, so moving the
+	// iris 0.06 across turns the eye 30 degrees.
+	sin30 := math.Sin(math.Pi / 6)
+	cos30 := math.Cos(math.Pi / 6)
+
+	tests := []struct {
+		name   string
+		turn   [3]float64
+		iris   vector.Vector3
+		want   vector.Vector3
+		remove bool
+	}{
+		{"straight ahead", [3]float64{}, vector.Vector3Zero(), vector.NewVector3(0, 0, -1), false},
+		{"looking left", [3]float64{}, vector.NewVector3(0.06, 0, 0), vector.NewVector3(sin30, 0, -cos30), false},
+		{"looking up", [3]float64{}, vector.NewVector3(0, 0.06, 0), vector.NewVector3(0, sin30, -cos30), false},
+		{"iris past the eyeball", [3]float64{}, vector.NewVector3(0.5, 0, 0), vector.NewVector3(1, 0, 0), false},
+		{"head turned", [3]float64{0, 40, 0}, vector.Vector3Zero(), eulerRotation(0, 40, 0).apply(vector.NewVector3(0, 0, -1)), false},
+		{"iris missing", [3]float64{}, vector.Vector3Zero(), vector.Vector3Zero(), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := eulerRotation(tc.turn[0], tc.turn[1], tc.turn[2])
+			pose := headPose{rotation: r}
+
+			// The face within the recording's space, where y points up,
+			// with the left iris moved.
+			face := syntheticFace(neutralFace)
+			fp := facePoints{pos: make([]vector.Vector3, len(face)), found: make([]bool, len(face))}
+			for i, p := range face {
+				p = vector.NewVector3(p.X(), -p.Y(), p.Z())
+				if i == leftIrisCenter {
+					p = vector.NewVector3(0.35, 0, 0).Add(tc.iris)
+				}
+				fp.pos[i] = r.apply(p)
+				fp.found[i] = !(tc.remove && i == leftIrisCenter)
+			}
+
+			got, ok := gazeDirection(pose, fp, faceSides[0])
+			if ok == tc.remove {
+				t.Fatalf("got ok %v, want %v", ok, !tc.remove)
+			}
+			if got.Distance(tc.want) > 1e-9 {
+				t.Fatalf("got direction %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package face
+
+import (
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/landmark"
+)
+
+// Landmarks of mediapipe's face mesh that expressions are measured from. Left
+// and right are the subject's own, so the left eye appears on the right of
+// the image.
+const (
+	foreheadTop = 10
+	noseTip     = 1
+	noseBottom  = 2
+	chin        = 152
+
+	rightEyeOuter = 33
+	rightEyeInner = 133
+	rightEyeUpper = 159
+	rightEyeLower = 145
+	leftEyeOuter  = 263
+	leftEyeInner  = 362
+	leftEyeUpper  = 386
+	leftEyeLower  = 374
+
+	// The upper and lower lids of each eye, a third of the way in from either
+	// corner.
+	rightEyeUpperOuter = 160
+	rightEyeUpperInner = 158
+	rightEyeLowerOuter = 144
+	rightEyeLowerInner = 153
+	leftEyeUpperOuter  = 387
+	leftEyeUpperInner  = 385
+	leftEyeLowerOuter  = 373
+	leftEyeLowerInner  = 380
+
+	rightIrisCenter = 468
+	leftIrisCenter  = 473
+
+	rightBrowInner = 107
+	rightBrowOuter = 70
+	leftBrowInner  = 336
+	leftBrowOuter  = 300
+
+	rightNostril = 98
+	leftNostril  = 327
+	rightCheek   = 205
+	leftCheek    = 425
+
+	mouthRightCorner = 61
+	mouthLeftCorner  = 291
+	upperLipTop      = 0
+	upperLipInner    = 13
+	lowerLipInner    = 14
+	lowerLipBottom   = 17
+	upperLipRight    = 40
+	upperLipLeft     = 270
+	lowerLipRight    = 91
+	lowerLipLeft     = 321
+)
+
+// facePoints are the positions of a single face's landmarks within a frame,
+// indexed by landmark.
+type facePoints struct {
+	pos   []vector.Vector3
+	found []bool
+}
+
+func newFacePoints(marks []landmark.LandMark) facePoints {
+	points := facePoints{}
+	for _, mark := range marks {
+		if mark.ID < 0 {
+			continue
+		}
+		for len(points.pos) < mark.ID+1 {
+			points.pos = append(points.pos, vector.Vector3Zero())
+			points.found = append(points.found, false)
+		}
+		points.pos[mark.ID] = vector.NewVector3(mark.X, mark.Y, mark.Z)
+		points.found[mark.ID] = true
+	}
+	return points
+}
+
+// has is whether every one of the landmarks was found.
+func (fp facePoints) has(landmarks ...int) bool {
+	for _, i := range landmarks {
+		if i >= len(fp.found) || !fp.found[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// typicalHeadHeight is the distance from beneath the nose to the top of the
+// forehead of a typical adult face, in units of the distance between the
+// outer corners of the eyes.
+const typicalHeadHeight = 1.2
+
+// headFrame is a coordinate system that moves with the head, letting the
+// face be measured the same no matter how the head is turned or how far it
+// is from the camera. X points towards the subject's left, Y up through the
+// top of the head, and Z out of the face. Distances are in units of the
+// distance between the outer corners of the eyes.
+type headFrame struct {
+	origin  vector.Vector3
+	right   vector.Vector3
+	up      vector.Vector3
+	forward vector.Vector3
+	scale   float64
+
+	// height is the distance from beneath the nose to the top of the
+	// forehead, along up, in the detector's coordinates.
+	height float64
+}
+
+// newHeadFrame builds the head's frame out of the corners of the eyes and the
+// line running from beneath the nose to the top of the forehead, neither of
+// which move as the jaw opens. ok is false if any of those landmarks are
+// missing or the face is degenerate.
+func newHeadFrame(fp facePoints) (frame headFrame, ok bool) {
+	if !fp.has(rightEyeOuter, leftEyeOuter, foreheadTop, noseBottom) {
+		return headFrame{}, false
+	}
+
+	across := fp.pos[leftEyeOuter].Sub(fp.pos[rightEyeOuter])
+	scale := across.Length()
+	if scale == 0 {
+		return headFrame{}, false
+	}
+	right := across.DivByConstant(scale)
+
+	// The nose to forehead line isn't quite perpendicular to the eyes, so
+	// only the part of it that is perpendicular is kept.
+	vertical := fp.pos[foreheadTop].Sub(fp.pos[noseBottom])
+	vertical = vertical.Sub(right.MultByConstant(vertical.Dot(right)))
+	if vertical.Length() == 0 {
+		return headFrame{}, false
+	}
+	up := vertical.Normalized()
+
+	return headFrame{
+		origin:  fp.pos[rightEyeOuter].Add(fp.pos[leftEyeOuter]).DivByConstant(2),
+		right:   right,
+		up:      up,
+		forward: right.Cross(up),
+		scale:   scale,
+		height:  vertical.Length(),
+	}, true
+}
+
+// local moves a point from the detector's coordinates into the head's frame.
+func (hf headFrame) local(p vector.Vector3) vector.Vector3 {
+	d := p.Sub(hf.origin)
+	return vector.NewVector3(
+		d.Dot(hf.right)/hf.scale,
+		d.Dot(hf.up)/hf.scale,
+		d.Dot(hf.forward)/hf.scale,
+	)
+}
+
+// measured moves a point into the head's frame like local, except heights are
+// measured against the height of the head rather than the width across its
+// eyes. The detector normalizes x and y by the video's width and height
+// separately, stretching the face with the video's aspect ratio, which
+// measuring each axis against a length along that same axis cancels out for
+// an upright face. Heights are scaled so a typical face measures the same as
+// it would with local in square video.
+func (hf headFrame) measured(p vector.Vector3) vector.Vector3 {
+	d := p.Sub(hf.origin)
+	return vector.NewVector3(
+		d.Dot(hf.right)/hf.scale,
+		d.Dot(hf.up)/hf.height*typicalHeadHeight,
+		d.Dot(hf.forward)/hf.scale,
+	)
+}
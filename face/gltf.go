@@ -0,0 +1,446 @@
+package face
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// glTF constants used by the exporter.
+const (
+	glbMagic     = 0x46546C67 // "glTF"
+	glbVersion   = 2
+	glbChunkJSON = 0x4E4F534A // "JSON"
+	glbChunkBIN  = 0x004E4942 // "BIN"
+
+	gltfFloat         = 5126
+	gltfUnsignedShort = 5123
+	gltfArrayBuffer   = 34962
+	gltfElementBuffer = 34963
+)
+
+// gltfTargetsPerMesh is the most morph targets given to a single mesh. Long
+// clips are split into chunks of frames, each its own mesh, so no mesh
+// carries more targets than engines are willing to load.
+const gltfTargetsPerMesh = 32
+
+type gltfSparseIndices struct {
+	BufferView    int `json:"bufferView"`
+	ComponentType int `json:"componentType"`
+}
+
+type gltfSparseValues struct {
+	BufferView int `json:"bufferView"`
+}
+
+// gltfSparse lists the only elements of an accessor that aren't zero.
+type gltfSparse struct {
+	Count   int               `json:"count"`
+	Indices gltfSparseIndices `json:"indices"`
+	Values  gltfSparseValues  `json:"values"`
+}
+
+type gltfAccessor struct {
+	BufferView    *int        `json:"bufferView,omitempty"`
+	ComponentType int         `json:"componentType"`
+	Count         int         `json:"count"`
+	Type          string      `json:"type"`
+	Min           []float64   `json:"min,omitempty"`
+	Max           []float64   `json:"max,omitempty"`
+	Sparse        *gltfSparse `json:"sparse,omitempty"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target,omitempty"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int   `json:"attributes"`
+	Indices    int              `json:"indices"`
+	Targets    []map[string]int `json:"targets,omitempty"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name"`
+	Primitives []gltfPrimitive `json:"primitives"`
+	Weights    []float64       `json:"weights,omitempty"`
+}
+
+type gltfNode struct {
+	Name  string    `json:"name"`
+	Mesh  int       `json:"mesh"`
+	Scale []float64 `json:"scale,omitempty"`
+}
+
+type gltfChannelTarget struct {
+	Node int    `json:"node"`
+	Path string `json:"path"`
+}
+
+type gltfChannel struct {
+	Sampler int               `json:"sampler"`
+	Target  gltfChannelTarget `json:"target"`
+}
+
+type gltfSampler struct {
+	Input         int    `json:"input"`
+	Output        int    `json:"output"`
+	Interpolation string `json:"interpolation"`
+}
+
+type gltfAnimation struct {
+	Name     string        `json:"name"`
+	Channels []gltfChannel `json:"channels"`
+	Samplers []gltfSampler `json:"samplers"`
+}
+
+type gltfScene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type gltfBuffer struct {
+	ByteLength int `json:"byteLength"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes"`
+	Animations  []gltfAnimation  `json:"animations,omitempty"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+}
+
+// gltfBuilder accumulates the binary buffer and the accessors describing it.
+type gltfBuilder struct {
+	doc gltfDocument
+	bin bytes.Buffer
+}
+
+func (gb *gltfBuilder) view(data interface{}, target int) *int {
+	for gb.bin.Len()%4 != 0 {
+		gb.bin.WriteByte(0)
+	}
+	offset := gb.bin.Len()
+	binary.Write(&gb.bin, binary.LittleEndian, data)
+
+	gb.doc.BufferViews = append(gb.doc.BufferViews, gltfBufferView{
+		ByteOffset: offset,
+		ByteLength: gb.bin.Len() - offset,
+		Target:     target,
+	})
+	view := len(gb.doc.BufferViews) - 1
+	return &view
+}
+
+func (gb *gltfBuilder) accessor(accessor gltfAccessor) int {
+	gb.doc.Accessors = append(gb.doc.Accessors, accessor)
+	return len(gb.doc.Accessors) - 1
+}
+
+func (gb *gltfBuilder) vec3s(points []vector.Vector3, target int) int {
+	data := make([]float32, 0, len(points)*3)
+	min := []float64{math.Inf(1), math.Inf(1), math.Inf(1)}
+	max := []float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+	for _, p := range points {
+		for i, component := range []float64{p.X(), p.Y(), p.Z()} {
+			// Bounds are taken after rounding so they match the stored data.
+			rounded := float64(float32(component))
+			data = append(data, float32(component))
+			min[i] = math.Min(min[i], rounded)
+			max[i] = math.Max(max[i], rounded)
+		}
+	}
+	return gb.accessor(gltfAccessor{
+		BufferView:    gb.view(data, target),
+		ComponentType: gltfFloat,
+		Count:         len(points),
+		Type:          "VEC3",
+		Min:           min,
+		Max:           max,
+	})
+}
+
+func (gb *gltfBuilder) scalars(values []float32, bounds bool) int {
+	accessor := gltfAccessor{
+		BufferView:    gb.view(values, 0),
+		ComponentType: gltfFloat,
+		Count:         len(values),
+		Type:          "SCALAR",
+	}
+	if bounds {
+		min, max := math.Inf(1), math.Inf(-1)
+		for _, v := range values {
+			min = math.Min(min, float64(v))
+			max = math.Max(max, float64(v))
+		}
+		accessor.Min = []float64{min}
+		accessor.Max = []float64{max}
+	}
+	return gb.accessor(accessor)
+}
+
+// gltfSpace moves a point out of the recording's space, which is left
+// handed, into glTF's right handed space with the face looking down +Z.
+func gltfSpace(v vector.Vector3) vector.Vector3 {
+	return vector.NewVector3(v.X(), v.Y(), -v.Z())
+}
+
+// positionAt is where the captures place a vertex at the time, interpolating
+// between captures when the vertex wasn't captured at exactly that time.
+func positionAt(captures []position.Capture, t float64) vector.Vector3 {
+	i := sort.Search(len(captures), func(i int) bool {
+		return captures[i].Time() >= t
+	})
+	if i == 0 {
+		return captures[0].Position()
+	}
+	if i == len(captures) {
+		return captures[len(captures)-1].Position()
+	}
+
+	before, after := captures[i-1], captures[i]
+	span := after.Time() - before.Time()
+	if span <= 0 {
+		return after.Position()
+	}
+	start := before.Position()
+	return start.Add(after.Position().Sub(start).MultByConstant((t - before.Time()) / span))
+}
+
+// keyframeTimes are the times of the frames the face was seen in that can key
+// its animation, which glTF requires to strictly increase. Frames sharing a
+// time with the frame before them, once stored as 32 bit floats, are dropped
+// as the earlier frame already keys that time.
+func keyframeTimes(seen []float64) ([]float64, error) {
+	times := make([]float64, 0, len(seen))
+	for _, t := range seen {
+		if len(times) > 0 {
+			last := times[len(times)-1]
+			if float32(t) == float32(last) {
+				continue
+			}
+			if t < last {
+				return nil, fmt.Errorf("frame at %gs comes after a frame at %gs, glTF animations require increasing times", t, last)
+			}
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// addFace adds the face's mesh, built from the face's first frame, with a
+// morph target per keyframe time, animated by blending from one frame's
+// target to the next. Frames are split into chunks of at most
+// gltfTargetsPerMesh, each its own node and mesh shown only for the stretch
+// of time its frames cover. Neighboring chunks share the frame they meet at,
+// so playback blends across chunks without a jump.
+func (gb *gltfBuilder) addFace(faceIndex int, captures [][]position.Capture, tris [][3]int, vertices int, seen []float64) {
+	base := make([]vector.Vector3, vertices)
+	for v := range base {
+		base[v] = gltfSpace(positionAt(captures[v], seen[0]))
+	}
+	baseAccessor := gb.vec3s(base, gltfArrayBuffer)
+
+	indices := make([]uint16, 0, len(tris)*3)
+	for _, tri := range tris {
+		indices = append(indices, uint16(tri[0]), uint16(tri[1]), uint16(tri[2]))
+	}
+	indicesAccessor := gb.accessor(gltfAccessor{
+		BufferView:    gb.view(indices, gltfElementBuffer),
+		ComponentType: gltfUnsignedShort,
+		Count:         len(indices),
+		Type:          "SCALAR",
+	})
+
+	animation := gltfAnimation{
+		Name:     fmt.Sprintf("Face %d", faceIndex),
+		Channels: make([]gltfChannel, 0),
+		Samplers: make([]gltfSampler, 0),
+	}
+	addChannel := func(node int, path string, input, output int, interpolation string) {
+		animation.Samplers = append(animation.Samplers, gltfSampler{
+			Input:         input,
+			Output:        output,
+			Interpolation: interpolation,
+		})
+		animation.Channels = append(animation.Channels, gltfChannel{
+			Sampler: len(animation.Samplers) - 1,
+			Target:  gltfChannelTarget{Node: node, Path: path},
+		})
+	}
+
+	for start := 0; ; start += gltfTargetsPerMesh - 1 {
+		end := start + gltfTargetsPerMesh - 1
+		if end > len(seen)-1 {
+			end = len(seen) - 1
+		}
+		frames := seen[start : end+1]
+		chunk := start / (gltfTargetsPerMesh - 1)
+
+		targets := make([]map[string]int, len(frames))
+		for frame, t := range frames {
+			displacements := make([]vector.Vector3, vertices)
+			for v := range displacements {
+				displacements[v] = gltfSpace(positionAt(captures[v], t)).Sub(base[v])
+			}
+			targets[frame] = map[string]int{"POSITION": gb.vec3s(displacements, gltfArrayBuffer)}
+		}
+
+		gb.doc.Meshes = append(gb.doc.Meshes, gltfMesh{
+			Name: fmt.Sprintf("Face %d Chunk %d", faceIndex, chunk),
+			Primitives: []gltfPrimitive{{
+				Attributes: map[string]int{"POSITION": baseAccessor},
+				Indices:    indicesAccessor,
+				Targets:    targets,
+			}},
+			Weights: make([]float64, len(frames)),
+		})
+		node := gltfNode{
+			Name: fmt.Sprintf("face-%d-chunk-%d", faceIndex, chunk),
+			Mesh: len(gb.doc.Meshes) - 1,
+		}
+		if start > 0 {
+			node.Scale = []float64{0, 0, 0}
+		}
+		gb.doc.Nodes = append(gb.doc.Nodes, node)
+		nodeIndex := len(gb.doc.Nodes) - 1
+		gb.doc.Scenes[0].Nodes = append(gb.doc.Scenes[0].Nodes, nodeIndex)
+
+		// Every keyframe fully weighs its own frame's target, so linearly
+		// interpolating between keyframes blends one frame into the next.
+		// Only those weights are stored, with every other left zero.
+		times := make([]float32, len(frames))
+		weightIndices := make([]uint16, len(frames))
+		weightValues := make([]float32, len(frames))
+		for frame, t := range frames {
+			times[frame] = float32(t)
+			weightIndices[frame] = uint16((frame * len(frames)) + frame)
+			weightValues[frame] = 1
+		}
+		weights := gb.accessor(gltfAccessor{
+			ComponentType: gltfFloat,
+			Count:         len(frames) * len(frames),
+			Type:          "SCALAR",
+			Sparse: &gltfSparse{
+				Count: len(frames),
+				Indices: gltfSparseIndices{
+					BufferView:    *gb.view(weightIndices, 0),
+					ComponentType: gltfUnsignedShort,
+				},
+				Values: gltfSparseValues{BufferView: *gb.view(weightValues, 0)},
+			},
+		})
+		addChannel(nodeIndex, "weights", gb.scalars(times, true), weights, "LINEAR")
+
+		// A single chunk is always shown. Otherwise each chunk is scaled down
+		// to nothing outside of the frames it covers.
+		if start > 0 || end < len(seen)-1 {
+			scaleTimes := []float32{float32(seen[0])}
+			scales := make([]vector.Vector3, 0, 3)
+			if start > 0 {
+				scales = append(scales, vector.Vector3Zero())
+				scaleTimes = append(scaleTimes, float32(frames[0]))
+			}
+			scales = append(scales, vector.Vector3One())
+			if end < len(seen)-1 {
+				scales = append(scales, vector.Vector3Zero())
+				scaleTimes = append(scaleTimes, float32(frames[len(frames)-1]))
+			}
+			addChannel(nodeIndex, "scale", gb.scalars(scaleTimes, true), gb.vec3s(scales, 0), "STEP")
+		}
+
+		if end == len(seen)-1 {
+			break
+		}
+	}
+	gb.doc.Animations = append(gb.doc.Animations, animation)
+}
+
+func (gb *gltfBuilder) write(out io.Writer) error {
+	for gb.bin.Len()%4 != 0 {
+		gb.bin.WriteByte(0)
+	}
+	gb.doc.Buffers = []gltfBuffer{{ByteLength: gb.bin.Len()}}
+
+	doc, err := json.Marshal(gb.doc)
+	if err != nil {
+		return err
+	}
+	for len(doc)%4 != 0 {
+		doc = append(doc, ' ')
+	}
+
+	// Every length within a GLB is stored in 32 bits.
+	total := int64(12+8+len(doc)+8) + int64(gb.bin.Len())
+	if total > math.MaxUint32 {
+		return fmt.Errorf("glTF would be %d bytes, exceeding the %d byte limit of a binary glTF", total, uint32(math.MaxUint32))
+	}
+
+	header := []uint32{
+		glbMagic,
+		glbVersion,
+		uint32(total),
+		uint32(len(doc)),
+		glbChunkJSON,
+	}
+	if err := binary.Write(out, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if _, err := out.Write(doc); err != nil {
+		return err
+	}
+	if err := binary.Write(out, binary.LittleEndian, []uint32{uint32(gb.bin.Len()), glbChunkBIN}); err != nil {
+		return err
+	}
+	_, err = out.Write(gb.bin.Bytes())
+	return err
+}
+
+func (rd *runningData) writeGLB(out io.Writer) error {
+	gb := &gltfBuilder{
+		doc: gltfDocument{
+			Asset:  gltfAsset{Version: "2.0", Generator: "recolude landmark-recordings"},
+			Scenes: []gltfScene{{Nodes: make([]int, 0)}},
+		},
+	}
+
+	vertices := rd.model.MeshVertices()
+	if vertices > math.MaxUint16+1 {
+		return fmt.Errorf("face mesh has %d vertices, more than the %d a glTF mesh with 16 bit indices can address", vertices, math.MaxUint16+1)
+	}
+	for faceIndex, track := range rd.faces {
+		if !track.complete(vertices) {
+			continue
+		}
+		times, err := keyframeTimes(track.Seen)
+		if err != nil {
+			return fmt.Errorf("face %d: %w", faceIndex, err)
+		}
+		gb.addFace(faceIndex, track.Centered(rd.aabb), rd.model.Triangles, vertices, times)
+	}
+
+	if len(gb.doc.Meshes) == 0 {
+		return errors.New("no face was seen with a complete mesh")
+	}
+	return gb.write(out)
+}
@@ -0,0 +1,162 @@
+package face
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+)
+
+func TestKeyframeTimes(t *testing.T) {
+	tests := []struct {
+		name    string
+		seen    []float64
+		want    []float64
+		wantErr string
+	}{
+		{"increasing", []float64{0, 0.5, 1}, []float64{0, 0.5, 1}, ""},
+		{"duplicate dropped", []float64{0, 0.5, 0.5, 1}, []float64{0, 0.5, 1}, ""},
+		{"equal as 32 bit floats dropped", []float64{0, 1000, 1000.00001}, []float64{0, 1000}, ""},
+		{"single frame", []float64{2}, []float64{2}, ""},
+		{"decreasing", []float64{0, 1, 0.5}, nil, "frame at 0.5s comes after a frame at 1s"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := keyframeTimes(tc.seen)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+// readGLB checks the header and chunks of a binary glTF, returning the
+// document within its JSON chunk.
+func readGLB(t *testing.T, glb []byte) gltfDocument {
+	t.Helper()
+
+	var header [5]uint32
+	if err := binary.Read(bytes.NewReader(glb), binary.LittleEndian, &header); err != nil {
+		t.Fatal(err)
+	}
+	if header[0] != glbMagic || header[1] != glbVersion || int(header[2]) != len(glb) || header[4] != glbChunkJSON {
+		t.Fatalf("got header %x for %d bytes", header, len(glb))
+	}
+
+	jsonEnd := 20 + int(header[3])
+	doc := gltfDocument{}
+	if err := json.Unmarshal(glb[20:jsonEnd], &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var bin [2]uint32
+	if err := binary.Read(bytes.NewReader(glb[jsonEnd:]), binary.LittleEndian, &bin); err != nil {
+		t.Fatal(err)
+	}
+	if bin[1] != glbChunkBIN || jsonEnd+8+int(bin[0]) != len(glb) || len(doc.Buffers) != 1 || doc.Buffers[0].ByteLength != int(bin[0]) {
+		t.Fatalf("got binary chunk %x after %d bytes of %d", bin, jsonEnd, len(glb))
+	}
+	return doc
+}
+
+func TestWriteGLB(t *testing.T) {
+	face := syntheticFace(neutralFace)
+	builder := NewConverter(30).NewBuilder()
+
+	// 40 frames, one sharing its time with the frame before it, leaves 39
+	// keyframes split across a chunk of 32 and a chunk of 8 sharing a frame.
+	for i := 0; i < 40; i++ {
+		curTime := float64(i) / 30
+		if i == 20 {
+			curTime = float64(i-1) / 30
+		}
+		if err := builder.Add(curTime, placeFace(face, 0, 0.5+float64(i)*0.001, 0.5, 0.1, 0.1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := bytes.Buffer{}
+	if err := builder.WriteGLB(&out); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	doc := readGLB(t, out.Bytes())
+
+	if len(doc.Meshes) != 2 || len(doc.Nodes) != 2 || len(doc.Scenes[0].Nodes) != 2 || len(doc.Animations) != 1 {
+		t.Fatalf("got %d meshes, %d nodes and %d animations, want 2, 2 and 1", len(doc.Meshes), len(doc.Nodes), len(doc.Animations))
+	}
+	for i, want := range []int{32, 8} {
+		if got := len(doc.Meshes[i].Primitives[0].Targets); got != want {
+			t.Fatalf("chunk %d: got %d morph targets, want %d", i, got, want)
+		}
+	}
+
+	vertices := doc.Accessors[doc.Meshes[0].Primitives[0].Attributes["POSITION"]].Count
+	if vertices != MediaPipe.MeshVertices() {
+		t.Fatalf("got %d vertices, want %d", vertices, MediaPipe.MeshVertices())
+	}
+}
+
+func TestWriteGLBErrors(t *testing.T) {
+	face := syntheticFace(neutralFace)
+
+	tests := []struct {
+		name    string
+		frames  [][]landmark.LandMark
+		times   []float64
+		wantErr string
+	}{
+		{
+			name:    "no faces",
+			wantErr: "no face was seen with a complete mesh",
+		},
+		{
+			name:    "incomplete mesh",
+			frames:  [][]landmark.LandMark{placeFace(face[:100], 0, 0.5, 0.5, 0.1, 0.1)},
+			times:   []float64{0},
+			wantErr: "no face was seen with a complete mesh",
+		},
+		{
+			name: "decreasing times",
+			frames: [][]landmark.LandMark{
+				placeFace(face, 0, 0.5, 0.5, 0.1, 0.1),
+				placeFace(face, 0, 0.5, 0.5, 0.1, 0.1),
+			},
+			times:   []float64{1, 0.5},
+			wantErr: "face 0: frame at 0.5s comes after a frame at 1s",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := NewConverter(30).NewBuilder()
+			for i, marks := range tc.frames {
+				if err := builder.Add(tc.times[i], marks); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := builder.WriteGLB(&bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package face
+
+import (
+	"math"
+	"sort"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format/collection/euler"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// stableLandmarks are landmarks of the forehead, nose bridge, eye corners and
+// temples, which keep their shape as the face changes expression. The head
+// is rigidly fit to these alone so expressions don't disturb its pose.
+var stableLandmarks = []int{
+	10, 151, 9, 8, 168, 6, 197, 195, 5, 4, 1, noseBottom,
+	rightEyeOuter, rightEyeInner, leftEyeOuter, leftEyeInner,
+	127, 356, 234, 454, 21, 251, 54, 284,
+}
+
+// rotation is a 3x3 rotation matrix, indexed by row then column.
+type rotation [3][3]float64
+
+func (r rotation) apply(v vector.Vector3) vector.Vector3 {
+	return vector.NewVector3(
+		r[0][0]*v.X()+r[0][1]*v.Y()+r[0][2]*v.Z(),
+		r[1][0]*v.X()+r[1][1]*v.Y()+r[1][2]*v.Z(),
+		r[2][0]*v.X()+r[2][1]*v.Y()+r[2][2]*v.Z(),
+	)
+}
+
+func (r rotation) transposed() rotation {
+	var out rotation
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			out[row][col] = r[col][row]
+		}
+	}
+	return out
+}
+
+// eulerZXY decomposes the rotation into the angles, in degrees, about X, Y
+// and Z that compose it when applied about Z, then X, then Y, which is how
+// recolude applies euler rotations.
+func (r rotation) eulerZXY() (x, y, z float64) {
+	sx := math.Max(-1, math.Min(1, -r[1][2]))
+	x = math.Asin(sx)
+	if math.Abs(sx) < 1-1e-9 {
+		y = math.Atan2(r[0][2], r[2][2])
+		z = math.Atan2(r[1][0], r[1][1])
+	} else {
+		y = math.Atan2(-r[2][0], r[0][0])
+	}
+	return x * 180 / math.Pi, y * 180 / math.Pi, z * 180 / math.Pi
+}
+
+// quaternionRotation builds the rotation matrix of the unit quaternion.
+func quaternionRotation(w, x, y, z float64) rotation {
+	return rotation{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// largestEigenvector finds the eigenvector of the symmetric matrix with the
+// largest eigenvalue using Jacobi's eigenvalue algorithm.
+func largestEigenvector(m [4][4]float64) [4]float64 {
+	vectors := [4][4]float64{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+
+	for sweep := 0; sweep < 50; sweep++ {
+		off := 0.
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				off += m[p][q] * m[p][q]
+			}
+		}
+		if off < 1e-22 {
+			break
+		}
+
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				if m[p][q] == 0 {
+					continue
+				}
+
+				// Rotate rows and columns p and q so m[p][q] becomes zero.
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < 4; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p] = c*mkp - s*mkq
+					m[k][q] = s*mkp + c*mkq
+				}
+				for k := 0; k < 4; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k] = c*mpk - s*mqk
+					m[q][k] = s*mpk + c*mqk
+				}
+				for k := 0; k < 4; k++ {
+					vkp, vkq := vectors[k][p], vectors[k][q]
+					vectors[k][p] = c*vkp - s*vkq
+					vectors[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+
+	best := 0
+	for i := 1; i < 4; i++ {
+		if m[i][i] > m[best][best] {
+			best = i
+		}
+	}
+	return [4]float64{vectors[0][best], vectors[1][best], vectors[2][best], vectors[3][best]}
+}
+
+// fitRotation finds the rotation that best takes the canonical points onto
+// the points, both already centered on their centroids, in the least squares
+// sense. The rotation is solved with Horn's quaternion method, which unlike
+// solving with an SVD never produces a reflection.
+func fitRotation(canonical, points []vector.Vector3) rotation {
+	var s [3][3]float64
+	for i, c := range canonical {
+		a := [3]float64{c.X(), c.Y(), c.Z()}
+		b := [3]float64{points[i].X(), points[i].Y(), points[i].Z()}
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				s[row][col] += a[row] * b[col]
+			}
+		}
+	}
+
+	xx, xy, xz := s[0][0], s[0][1], s[0][2]
+	yx, yy, yz := s[1][0], s[1][1], s[1][2]
+	zx, zy, zz := s[2][0], s[2][1], s[2][2]
+	q := largestEigenvector([4][4]float64{
+		{xx + yy + zz, yz - zy, zx - xz, xy - yx},
+		{yz - zy, xx - yy - zz, xy + yx, zx + xz},
+		{zx - xz, xy + yx, -xx + yy - zz, yz + zy},
+		{xy - yx, zx + xz, yz + zy, -xx - yy + zz},
+	})
+
+	length := math.Sqrt(q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3])
+	return quaternionRotation(q[0]/length, q[1]/length, q[2]/length, q[3]/length)
+}
+
+func centroid(points []vector.Vector3) vector.Vector3 {
+	sum := vector.Vector3Zero()
+	for _, p := range points {
+		sum = sum.Add(p)
+	}
+	return sum.DivByConstant(float64(len(points)))
+}
+
+// headPose is where the head was, and how it was turned, at a point in time.
+type headPose struct {
+	time     float64
+	position vector.Vector3
+	rotation rotation
+}
+
+// local moves a point from the recording's space into the head's.
+func (hp headPose) local(p vector.Vector3) vector.Vector3 {
+	return hp.rotation.transposed().apply(p.Sub(hp.position))
+}
+
+// eachFrame calls visit with the captures of every landmark at each time the
+// face was seen.
+func eachFrame(seen []float64, captures [][]position.Capture, visit func(t float64, fp facePoints)) {
+	cursors := make([]int, len(captures))
+	for _, t := range seen {
+		fp := facePoints{
+			pos:   make([]vector.Vector3, len(captures)),
+			found: make([]bool, len(captures)),
+		}
+		for i, landmark := range captures {
+			c := cursors[i]
+			if c < len(landmark) && landmark[c].Time() == t {
+				fp.pos[i] = landmark[c].Position()
+				fp.found[i] = true
+				cursors[i]++
+			}
+		}
+		visit(t, fp)
+	}
+}
+
+// solveHeadPoses fits the head to every frame of the face, with captures
+// already in the recording's space. The canonical head the frames are fit to
+// is the first frame every stable landmark was seen in, turned to look
+// straight down the recording's Z axis, so a head with no rotation is looking
+// straight at the camera.
+func solveHeadPoses(seen []float64, captures [][]position.Capture) []headPose {
+	poses := make([]headPose, 0, len(seen))
+
+	var canonical []vector.Vector3
+	points := make([]vector.Vector3, len(stableLandmarks))
+	eachFrame(seen, captures, func(t float64, fp facePoints) {
+		if !fp.has(stableLandmarks...) {
+			return
+		}
+
+		if canonical == nil {
+			hf, ok := newHeadFrame(fp)
+			if !ok {
+				return
+			}
+			canonical = make([]vector.Vector3, len(stableLandmarks))
+			for i, landmark := range stableLandmarks {
+				canonical[i] = hf.local(fp.pos[landmark]).MultByConstant(hf.scale)
+			}
+			center := centroid(canonical)
+			for i := range canonical {
+				canonical[i] = canonical[i].Sub(center)
+			}
+		}
+
+		for i, landmark := range stableLandmarks {
+			points[i] = fp.pos[landmark]
+		}
+		center := centroid(points)
+		for i := range points {
+			points[i] = points[i].Sub(center)
+		}
+
+		poses = append(poses, headPose{
+			time:     t,
+			position: center,
+			rotation: fitRotation(canonical, points),
+		})
+	})
+	return poses
+}
+
+// headPoseAt is the pose the head was last seen in at the time, or the first
+// pose it was seen in if the time comes before it.
+func headPoseAt(poses []headPose, t float64) headPose {
+	i := sort.Search(len(poses), func(i int) bool {
+		return poses[i].time > t
+	})
+	if i == 0 {
+		return poses[0]
+	}
+	return poses[i-1]
+}
+
+// headCollections builds the Position and Rotation collections of the head
+// out of its poses.
+func headCollections(poses []headPose) (position.Collection, euler.Collection) {
+	positions := make([]position.Capture, len(poses))
+	rotations := make([]euler.Capture, len(poses))
+	for i, pose := range poses {
+		positions[i] = position.NewCapture(pose.time, pose.position.X(), pose.position.Y(), pose.position.Z())
+		x, y, z := pose.rotation.eulerZXY()
+		rotations[i] = euler.NewEulerZXYCapture(pose.time, x, y, z)
+	}
+	return position.NewCollection(landmark.PositionCollectionName, positions), euler.NewCollection("Rotation", rotations)
+}
+
+// headLocal moves every capture of the face into the head's space, leaving
+// only the movement of the face's expressions.
+func headLocal(captures [][]position.Capture, poses []headPose) [][]position.Capture {
+	local := make([][]position.Capture, len(captures))
+	for i, landmark := range captures {
+		local[i] = make([]position.Capture, len(landmark))
+		for c, capture := range landmark {
+			pos := headPoseAt(poses, capture.Time()).local(capture.Position())
+			local[i][c] = position.NewCapture(capture.Time(), pos.X(), pos.Y(), pos.Z())
+		}
+	}
+	return local
+}
@@ -0,0 +1,148 @@
+package face
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// eulerRotation builds the rotation applying the angles, in degrees, about Z,
+// then X, then Y.
+func eulerRotation(x, y, z float64) rotation {
+	x, y, z = x*math.Pi/180, y*math.Pi/180, z*math.Pi/180
+	rx := rotation{{1, 0, 0}, {0, math.Cos(x), -math.Sin(x)}, {0, math.Sin(x), math.Cos(x)}}
+	ry := rotation{{math.Cos(y), 0, math.Sin(y)}, {0, 1, 0}, {-math.Sin(y), 0, math.Cos(y)}}
+	rz := rotation{{math.Cos(z), -math.Sin(z), 0}, {math.Sin(z), math.Cos(z), 0}, {0, 0, 1}}
+
+	mul := func(a, b rotation) rotation {
+		var out rotation
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				out[row][col] = a[row][0]*b[0][col] + a[row][1]*b[1][col] + a[row][2]*b[2][col]
+			}
+		}
+		return out
+	}
+	return mul(ry, mul(rx, rz))
+}
+
+func assertAngles(t *testing.T, r rotation, want [3]float64) {
+	t.Helper()
+	x, y, z := r.eulerZXY()
+	got := [3]float64{x, y, z}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-6 {
+			t.Fatalf("got x, y, z of %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRotationEulerZXY(t *testing.T) {
+	tests := []struct {
+		name string
+		want [3]float64
+	}{
+		{"none", [3]float64{0, 0, 0}},
+		{"nod", [3]float64{20, 0, 0}},
+		{"turn", [3]float64{0, -35, 0}},
+		{"tilt", [3]float64{0, 0, 15}},
+		{"combined", [3]float64{10, 25, -40}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assertAngles(t, eulerRotation(tc.want[0], tc.want[1], tc.want[2]), tc.want)
+		})
+	}
+}
+
+func TestFitRotation(t *testing.T) {
+	canonical := []vector.Vector3{
+		vector.NewVector3(1, 0, 0),
+		vector.NewVector3(-1, 0.5, 0),
+		vector.NewVector3(0, -1, 0.3),
+		vector.NewVector3(0, 0.5, -0.3),
+	}
+
+	tests := []struct {
+		name string
+		want [3]float64
+	}{
+		{"unturned", [3]float64{0, 0, 0}},
+		{"turned", [3]float64{-15, 30, 5}},
+		{"turned halfway around", [3]float64{0, 179, 0}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := eulerRotation(tc.want[0], tc.want[1], tc.want[2])
+			points := make([]vector.Vector3, len(canonical))
+			for i, p := range canonical {
+				points[i] = r.apply(p)
+			}
+			assertAngles(t, fitRotation(canonical, points), tc.want)
+		})
+	}
+}
+
+func TestSolveHeadPoses(t *testing.T) {
+	// The face within the recording's space, where y points up.
+	face := syntheticFace(neutralFace)
+	for i, p := range face {
+		face[i] = vector.NewVector3(p.X(), -p.Y(), p.Z())
+	}
+
+	turns := [][3]float64{{0, 0, 0}, {0, 30, 0}, {-10, 20, 5}}
+	offset := vector.NewVector3(0.5, -0.25, 2)
+	seen := make([]float64, len(turns))
+	captures := make([][]position.Capture, len(face))
+	for f, turn := range turns {
+		seen[f] = float64(f)
+		r := eulerRotation(turn[0], turn[1], turn[2])
+		for i, p := range face {
+			pos := r.apply(p).Add(offset)
+			captures[i] = append(captures[i], position.NewCapture(seen[f], pos.X(), pos.Y(), pos.Z()))
+		}
+	}
+
+	poses := solveHeadPoses(seen, captures)
+	if len(poses) != len(turns) {
+		t.Fatalf("got %d poses, want %d", len(poses), len(turns))
+	}
+	for i, turn := range turns {
+		if poses[i].time != seen[i] {
+			t.Fatalf("pose %d: got time %g, want %g", i, poses[i].time, seen[i])
+		}
+		assertAngles(t, poses[i].rotation, turn)
+	}
+
+	// Moving into the head's space undoes the turn, leaving every frame of
+	// the face the same.
+	local := headLocal(captures, poses)
+	for i := range local {
+		first := local[i][0].Position()
+		for _, capture := range local[i][1:] {
+			if capture.Position().Distance(first) > 1e-6 {
+				t.Fatalf("landmark %d: got %v then %v within the head's space", i, first, capture.Position())
+			}
+		}
+	}
+}
+
+func TestSolveHeadPosesSkipsIncompleteFrames(t *testing.T) {
+	face := syntheticFace(neutralFace)
+	captures := make([][]position.Capture, len(face))
+	for i, p := range face {
+		captures[i] = append(captures[i], position.NewCapture(1, p.X(), -p.Y(), p.Z()))
+		if i != stableLandmarks[0] {
+			captures[i] = append([]position.Capture{position.NewCapture(0, p.X(), -p.Y(), p.Z())}, captures[i]...)
+		}
+	}
+
+	poses := solveHeadPoses([]float64{0, 1}, captures)
+	if len(poses) != 1 || poses[0].time != 1 {
+		t.Fatalf("got poses %v, want the frame every stable landmark was seen in", poses)
+	}
+}
@@ -1,12 +0,0 @@
-package main
-
-var landmarkIrises = []Vector2Int{
-	NewVector2Int(475, 476),
-	NewVector2Int(477, 474),
-	NewVector2Int(469, 470),
-	NewVector2Int(472, 469),
-	NewVector2Int(471, 472),
-	NewVector2Int(474, 475),
-	NewVector2Int(476, 477),
-	NewVector2Int(470, 471),
-}
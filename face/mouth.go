@@ -0,0 +1,198 @@
+package face
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/recolude/rap/format/collection/enum"
+	"github.com/recolude/rap/format/collection/float"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// Visemes are the coarse mouth shapes each frame of a face is classified
+// into, named after the mouth shapes of Rhubarb Lip Sync so lip sync tracks
+// can drive characters drawn for it. Rhubarb's A, the closed mouth of M, B
+// and P, looks the same as a mouth at rest to the face mesh, so closed mouths
+// are always X.
+var Visemes = []string{
+	"X", // Rest, with the lips closed.
+	"B", // Slightly open, as in most consonants.
+	"C", // Open, as in "eh" and "ae".
+	"D", // Wide open, as in "aa".
+	"E", // Slightly rounded, as in "ao" and "er".
+	"F", // Puckered, as in "uw", "ow" and "w".
+}
+
+const (
+	visemeRest = iota
+	visemeSlightlyOpen
+	visemeOpen
+	visemeWideOpen
+	visemeRounded
+	visemePuckered
+)
+
+// mouthShape is how the mouth was held within a single frame.
+type mouthShape struct {
+	time float64
+
+	// open is the gap between the lips relative to the width of the mouth.
+	open float64
+
+	// width is the distance between the corners of the mouth, in units of
+	// the distance between the outer corners of the eyes.
+	width float64
+
+	// viseme indexes Visemes.
+	viseme int
+}
+
+// measureMouth measures how open and how wide the mouth is within the head's
+// frame, with the gap between the lips against the height of the head so the
+// video's aspect ratio doesn't skew it. ok is false if the landmarks of the
+// lips or the head's frame are missing.
+func measureMouth(fp facePoints) (open, width float64, ok bool) {
+	hf, ok := newHeadFrame(fp)
+	if !ok || !fp.has(mouthLeftCorner, mouthRightCorner, upperLipInner, lowerLipInner) {
+		return 0, 0, false
+	}
+
+	width = hf.measured(fp.pos[mouthLeftCorner]).Distance(hf.measured(fp.pos[mouthRightCorner]))
+	if width == 0 {
+		return 0, 0, false
+	}
+	gap := hf.measured(fp.pos[upperLipInner]).Distance(hf.measured(fp.pos[lowerLipInner]))
+	return gap / width, width, true
+}
+
+// classifyViseme picks the viseme of a mouth from how open and wide it is,
+// with the ranges being those of a typical adult face.
+func classifyViseme(open, width float64) int {
+	narrow := width < 0.46
+	switch {
+	case narrow && open < 0.25:
+		return visemePuckered
+	case narrow:
+		return visemeRounded
+	case open < 0.05:
+		return visemeRest
+	case open < 0.2:
+		return visemeSlightlyOpen
+	case open < 0.45:
+		return visemeOpen
+	default:
+		return visemeWideOpen
+	}
+}
+
+// mouthShapes measures the mouth within every frame it was seen in.
+func mouthShapes(seen []float64, captures [][]position.Capture) []mouthShape {
+	shapes := make([]mouthShape, 0, len(seen))
+	eachFrame(seen, captures, func(t float64, fp facePoints) {
+		open, width, ok := measureMouth(fp)
+		if !ok {
+			return
+		}
+		shapes = append(shapes, mouthShape{
+			time:   t,
+			open:   open,
+			width:  width,
+			viseme: classifyViseme(open, width),
+		})
+	})
+	return shapes
+}
+
+// mouthCollections builds the Mouth Open and Mouth Width float collections,
+// and the Viseme enum collection, out of the mouth's shapes.
+func mouthCollections(shapes []mouthShape) (float.Collection, float.Collection, enum.Collection) {
+	open := make([]float.Capture, len(shapes))
+	width := make([]float.Capture, len(shapes))
+	visemes := make([]enum.Capture, len(shapes))
+	for i, shape := range shapes {
+		open[i] = float.NewCapture(shape.time, shape.open)
+		width[i] = float.NewCapture(shape.time, shape.width)
+		visemes[i] = enum.NewCapture(shape.time, shape.viseme)
+	}
+	return float.NewCollection("Mouth Open", open),
+		float.NewCollection("Mouth Width", width),
+		enum.NewCollection("Viseme", Visemes, visemes)
+}
+
+// ErrMouthNeverSeen is returned when writing the lip sync of a face whose
+// mouth couldn't be measured within any frame.
+var ErrMouthNeverSeen = errors.New("mouth was never seen")
+
+type lipSyncCue struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Value string  `json:"value"`
+}
+
+type lipSyncMetadata struct {
+	Duration float64 `json:"duration"`
+}
+
+// lipSync is the JSON written by Rhubarb Lip Sync, a list of cues covering
+// the entire clip with the mouth shape held throughout each.
+type lipSync struct {
+	Metadata  lipSyncMetadata `json:"metadata"`
+	MouthCues []lipSyncCue    `json:"mouthCues"`
+}
+
+// lipSyncCues merges consecutive frames with the same viseme into a single
+// cue. The mouth is taken to be at rest before it was first seen and for any
+// stretch of time longer than maxGap it went unmeasured. The last frame
+// before each of those stretches, and the final frame, are held for
+// frameDuration.
+func lipSyncCues(shapes []mouthShape, maxGap, frameDuration float64) []lipSyncCue {
+	cues := make([]lipSyncCue, 0)
+	add := func(start float64, viseme int) {
+		if len(cues) > 0 {
+			last := &cues[len(cues)-1]
+			last.End = start
+			if last.Value == Visemes[viseme] {
+				return
+			}
+		}
+		cues = append(cues, lipSyncCue{Start: start, End: start, Value: Visemes[viseme]})
+	}
+
+	for i, shape := range shapes {
+		switch {
+		case i == 0 && shape.time > 0:
+			add(0, visemeRest)
+		case i > 0 && shape.time-shapes[i-1].time > maxGap:
+			add(math.Min(shapes[i-1].time+frameDuration, shape.time), visemeRest)
+		}
+		add(shape.time, shape.viseme)
+	}
+
+	if len(cues) > 0 {
+		cues[len(cues)-1].End = shapes[len(shapes)-1].time + frameDuration
+	}
+	return cues
+}
+
+func (rd *runningData) writeLipSync(out io.Writer, faceIndex int) error {
+	if faceIndex < 0 || faceIndex >= len(rd.faces) {
+		return fmt.Errorf("no face %d, %d faces were seen", faceIndex, len(rd.faces))
+	}
+	track := rd.faces[faceIndex]
+
+	shapes := mouthShapes(track.Seen, track.Captures)
+	if len(shapes) == 0 {
+		return fmt.Errorf("face %d: %w", faceIndex, ErrMouthNeverSeen)
+	}
+
+	cues := lipSyncCues(shapes, rd.maxGap, 1/rd.frameRate)
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(lipSync{
+		Metadata:  lipSyncMetadata{Duration: cues[len(cues)-1].End},
+		MouthCues: cues,
+	})
+}
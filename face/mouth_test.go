@@ -0,0 +1,172 @@
+package face
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+)
+
+func TestMeasureMouth(t *testing.T) {
+	tests := []struct {
+		name      string
+		lipGap    float64
+		scaleX    float64
+		wantOpen  float64
+		wantWidth float64
+	}{
+		{"closed", 0, 0.1, 0, 0.5},
+		{"open", 0.1, 0.1, 0.2, 0.5},
+		{"open in wide video", 0.1, 0.1 * 9 / 16, 0.2, 0.5},
+		{"wide open in tall video", 0.3, 0.1 * 16 / 9, 0.6, 0.5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			marks := placeFace(syntheticFace(faceShape{eyeOpening: 0.09, lipGap: tc.lipGap}), 0, 0.5, 0.5, tc.scaleX, 0.1)
+			open, width, ok := measureMouth(newFacePoints(marks))
+			if !ok {
+				t.Fatalf("got no measurement")
+			}
+			if math.Abs(open-tc.wantOpen) > 1e-9 || math.Abs(width-tc.wantWidth) > 1e-9 {
+				t.Fatalf("got open %g and width %g, want %g and %g", open, width, tc.wantOpen, tc.wantWidth)
+			}
+		})
+	}
+
+	if _, _, ok := measureMouth(newFacePoints(nil)); ok {
+		t.Fatalf("got a measurement of no face")
+	}
+}
+
+func TestClassifyViseme(t *testing.T) {
+	tests := []struct {
+		open  float64
+		width float64
+		want  string
+	}{
+		{0, 0.5, "X"},
+		{0.04, 0.5, "X"},
+		{0.1, 0.5, "B"},
+		{0.3, 0.5, "C"},
+		{0.6, 0.5, "D"},
+		{0.3, 0.4, "E"},
+		{0.1, 0.4, "F"},
+		{0, 0.4, "F"},
+	}
+
+	for _, tc := range tests {
+		if got := Visemes[classifyViseme(tc.open, tc.width)]; got != tc.want {
+			t.Fatalf("got %s for open %g and width %g, want %s", got, tc.open, tc.width, tc.want)
+		}
+	}
+}
+
+func TestLipSyncCues(t *testing.T) {
+	shapes := func(times []float64, visemes string) []mouthShape {
+		out := make([]mouthShape, len(times))
+		for i, curTime := range times {
+			for v, name := range Visemes {
+				if name == string(visemes[i]) {
+					out[i] = mouthShape{time: curTime, viseme: v}
+				}
+			}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		shapes []mouthShape
+		want   []lipSyncCue
+	}{
+		{
+			name:   "merged",
+			shapes: shapes([]float64{0, 0.25, 0.5, 0.75}, "XXCC"),
+			want:   []lipSyncCue{{0, 0.5, "X"}, {0.5, 1, "C"}},
+		},
+		{
+			name:   "rest before first seen",
+			shapes: shapes([]float64{1, 1.25}, "CD"),
+			want:   []lipSyncCue{{0, 1, "X"}, {1, 1.25, "C"}, {1.25, 1.5, "D"}},
+		},
+		{
+			name:   "rest before first seen merged",
+			shapes: shapes([]float64{1, 1.25}, "XX"),
+			want:   []lipSyncCue{{0, 1.5, "X"}},
+		},
+		{
+			name:   "rest while unseen",
+			shapes: shapes([]float64{0, 0.25, 2}, "CCC"),
+			want:   []lipSyncCue{{0, 0.5, "C"}, {0.5, 2, "X"}, {2, 2.25, "C"}},
+		},
+		{
+			name:   "none",
+			shapes: shapes(nil, ""),
+			want:   []lipSyncCue{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := lipSyncCues(tc.shapes, 0.3, 0.25)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got cues %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got cues %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteLipSync(t *testing.T) {
+	closed := syntheticFace(neutralFace)
+	open := syntheticFace(faceShape{eyeOpening: 0.09, lipGap: 0.3})
+
+	builder := NewConverter(4).NewBuilder()
+	for i, face := range [][]landmark.LandMark{
+		placeFace(closed, 0, 0.5, 0.5, 0.1*9/16, 0.1),
+		placeFace(open, 0, 0.5, 0.5, 0.1*9/16, 0.1),
+		placeFace(open, 0, 0.5, 0.5, 0.1*9/16, 0.1),
+	} {
+		if err := builder.Add(float64(i)/4, face); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := bytes.Buffer{}
+	if err := builder.WriteLipSync(&out, 0); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	got := lipSync{}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []lipSyncCue{{0, 0.25, "X"}, {0.25, 0.75, "D"}}
+	if got.Metadata.Duration != 0.75 || len(got.MouthCues) != len(want) || got.MouthCues[0] != want[0] || got.MouthCues[1] != want[1] {
+		t.Fatalf("got %+v, want cues %v lasting 0.75s", got, want)
+	}
+
+	if err := builder.WriteLipSync(&bytes.Buffer{}, 1); err == nil {
+		t.Fatalf("got no error writing a face that wasn't seen")
+	}
+}
+
+func TestWriteLipSyncMouthNeverSeen(t *testing.T) {
+	builder := NewConverter(30).NewBuilder()
+	if err := builder.Add(0, []landmark.LandMark{{ID: mouthLeftCorner}, {ID: mouthRightCorner}}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := builder.WriteLipSync(&bytes.Buffer{}, 0)
+	if !errors.Is(err, ErrMouthNeverSeen) {
+		t.Fatalf("got error %v, want ErrMouthNeverSeen", err)
+	}
+}
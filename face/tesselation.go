@@ -1,2561 +0,0 @@
-package main
-
-var lineSegments = []Vector2Int{
-	NewVector2Int(18, 17),
-	NewVector2Int(82, 38),
-	NewVector2Int(8, 9),
-	NewVector2Int(456, 248),
-	NewVector2Int(167, 2),
-	NewVector2Int(303, 271),
-	NewVector2Int(69, 104),
-	NewVector2Int(253, 450),
-	NewVector2Int(41, 42),
-	NewVector2Int(315, 16),
-	NewVector2Int(73, 74),
-	NewVector2Int(339, 373),
-	NewVector2Int(258, 385),
-	NewVector2Int(105, 63),
-	NewVector2Int(219, 48),
-	NewVector2Int(304, 272),
-	NewVector2Int(236, 217),
-	NewVector2Int(231, 22),
-	NewVector2Int(85, 84),
-	NewVector2Int(365, 367),
-	NewVector2Int(237, 218),
-	NewVector2Int(243, 112),
-	NewVector2Int(436, 427),
-	NewVector2Int(409, 410),
-	NewVector2Int(248, 456),
-	NewVector2Int(330, 266),
-	NewVector2Int(136, 135),
-	NewVector2Int(88, 95),
-	NewVector2Int(394, 430),
-	NewVector2Int(107, 108),
-	NewVector2Int(118, 117),
-	NewVector2Int(439, 438),
-	NewVector2Int(442, 443),
-	NewVector2Int(260, 466),
-	NewVector2Int(115, 48),
-	NewVector2Int(342, 276),
-	NewVector2Int(121, 128),
-	NewVector2Int(200, 421),
-	NewVector2Int(113, 124),
-	NewVector2Int(132, 137),
-	NewVector2Int(23, 230),
-	NewVector2Int(343, 357),
-	NewVector2Int(443, 444),
-	NewVector2Int(62, 78),
-	NewVector2Int(26, 155),
-	NewVector2Int(228, 110),
-	NewVector2Int(432, 434),
-	NewVector2Int(364, 379),
-	NewVector2Int(325, 308),
-	NewVector2Int(424, 430),
-	NewVector2Int(36, 205),
-	NewVector2Int(363, 420),
-	NewVector2Int(21, 162),
-	NewVector2Int(328, 462),
-	NewVector2Int(419, 351),
-	NewVector2Int(366, 345),
-	NewVector2Int(193, 8),
-	NewVector2Int(363, 456),
-	NewVector2Int(183, 80),
-	NewVector2Int(307, 375),
-	NewVector2Int(94, 2),
-	NewVector2Int(333, 284),
-	NewVector2Int(17, 313),
-	NewVector2Int(185, 40),
-	NewVector2Int(44, 1),
-	NewVector2Int(291, 287),
-	NewVector2Int(411, 352),
-	NewVector2Int(20, 238),
-	NewVector2Int(59, 166),
-	NewVector2Int(272, 310),
-	NewVector2Int(420, 437),
-	NewVector2Int(387, 260),
-	NewVector2Int(130, 226),
-	NewVector2Int(51, 45),
-	NewVector2Int(175, 377),
-	NewVector2Int(202, 214),
-	NewVector2Int(353, 383),
-	NewVector2Int(339, 448),
-	NewVector2Int(291, 408),
-	NewVector2Int(466, 260),
-	NewVector2Int(264, 454),
-	NewVector2Int(457, 440),
-	NewVector2Int(355, 429),
-	NewVector2Int(96, 89),
-	NewVector2Int(128, 121),
-	NewVector2Int(187, 207),
-	NewVector2Int(360, 278),
-	NewVector2Int(306, 307),
-	NewVector2Int(446, 467),
-	NewVector2Int(165, 39),
-	NewVector2Int(337, 151),
-	NewVector2Int(168, 193),
-	NewVector2Int(73, 41),
-	NewVector2Int(318, 403),
-	NewVector2Int(263, 467),
-	NewVector2Int(283, 445),
-	NewVector2Int(74, 42),
-	NewVector2Int(201, 83),
-	NewVector2Int(65, 222),
-	NewVector2Int(37, 11),
-	NewVector2Int(429, 355),
-	NewVector2Int(272, 408),
-	NewVector2Int(179, 180),
-	NewVector2Int(100, 36),
-	NewVector2Int(158, 157),
-	NewVector2Int(438, 440),
-	NewVector2Int(358, 331),
-	NewVector2Int(281, 456),
-	NewVector2Int(136, 138),
-	NewVector2Int(314, 404),
-	NewVector2Int(417, 8),
-	NewVector2Int(34, 127),
-	NewVector2Int(367, 416),
-	NewVector2Int(180, 181),
-	NewVector2Int(426, 322),
-	NewVector2Int(419, 197),
-	NewVector2Int(457, 309),
-	NewVector2Int(215, 138),
-	NewVector2Int(242, 241),
-	NewVector2Int(33, 247),
-	NewVector2Int(53, 225),
-	NewVector2Int(126, 209),
-	NewVector2Int(467, 359),
-	NewVector2Int(224, 223),
-	NewVector2Int(271, 304),
-	NewVector2Int(97, 2),
-	NewVector2Int(183, 191),
-	NewVector2Int(202, 204),
-	NewVector2Int(95, 78),
-	NewVector2Int(246, 247),
-	NewVector2Int(177, 147),
-	NewVector2Int(357, 343),
-	NewVector2Int(65, 55),
-	NewVector2Int(116, 137),
-	NewVector2Int(135, 150),
-	NewVector2Int(382, 256),
-	NewVector2Int(199, 171),
-	NewVector2Int(298, 293),
-	NewVector2Int(16, 315),
-	NewVector2Int(204, 106),
-	NewVector2Int(149, 170),
-	NewVector2Int(150, 135),
-	NewVector2Int(403, 315),
-	NewVector2Int(61, 57),
-	NewVector2Int(205, 187),
-	NewVector2Int(44, 4),
-	NewVector2Int(358, 429),
-	NewVector2Int(277, 355),
-	NewVector2Int(438, 309),
-	NewVector2Int(42, 80),
-	NewVector2Int(236, 174),
-	NewVector2Int(128, 232),
-	NewVector2Int(302, 268),
-	NewVector2Int(313, 314),
-	NewVector2Int(240, 64),
-	NewVector2Int(291, 375),
-	NewVector2Int(260, 387),
-	NewVector2Int(113, 225),
-	NewVector2Int(107, 65),
-	NewVector2Int(247, 225),
-	NewVector2Int(346, 347),
-	NewVector2Int(44, 125),
-	NewVector2Int(378, 379),
-	NewVector2Int(466, 263),
-	NewVector2Int(250, 459),
-	NewVector2Int(50, 205),
-	NewVector2Int(111, 35),
-	NewVector2Int(76, 77),
-	NewVector2Int(122, 193),
-	NewVector2Int(209, 198),
-	NewVector2Int(201, 194),
-	NewVector2Int(408, 407),
-	NewVector2Int(285, 336),
-	NewVector2Int(2, 393),
-	NewVector2Int(243, 190),
-	NewVector2Int(336, 9),
-	NewVector2Int(234, 227),
-	NewVector2Int(293, 276),
-	NewVector2Int(221, 28),
-	NewVector2Int(57, 185),
-	NewVector2Int(217, 174),
-	NewVector2Int(131, 134),
-	NewVector2Int(344, 278),
-	NewVector2Int(343, 399),
-	NewVector2Int(28, 158),
-	NewVector2Int(128, 245),
-	NewVector2Int(463, 464),
-	NewVector2Int(443, 257),
-	NewVector2Int(394, 364),
-	NewVector2Int(164, 167),
-	NewVector2Int(10, 109),
-	NewVector2Int(196, 236),
-	NewVector2Int(429, 358),
-	NewVector2Int(174, 217),
-	NewVector2Int(452, 341),
-	NewVector2Int(2, 370),
-	NewVector2Int(84, 180),
-	NewVector2Int(320, 307),
-	NewVector2Int(0, 37),
-	NewVector2Int(250, 328),
-	NewVector2Int(101, 119),
-	NewVector2Int(41, 74),
-	NewVector2Int(399, 456),
-	NewVector2Int(13, 12),
-	NewVector2Int(218, 115),
-	NewVector2Int(343, 412),
-	NewVector2Int(16, 17),
-	NewVector2Int(55, 8),
-	NewVector2Int(107, 55),
-	NewVector2Int(153, 22),
-	NewVector2Int(440, 344),
-	NewVector2Int(393, 269),
-	NewVector2Int(364, 434),
-	NewVector2Int(38, 41),
-	NewVector2Int(296, 336),
-	NewVector2Int(68, 63),
-	NewVector2Int(402, 317),
-	NewVector2Int(456, 437),
-	NewVector2Int(253, 374),
-	NewVector2Int(254, 339),
-	NewVector2Int(67, 104),
-	NewVector2Int(392, 309),
-	NewVector2Int(145, 23),
-	NewVector2Int(63, 70),
-	NewVector2Int(403, 318),
-	NewVector2Int(127, 234),
-	NewVector2Int(415, 292),
-	NewVector2Int(54, 21),
-	NewVector2Int(168, 6),
-	NewVector2Int(72, 38),
-	NewVector2Int(204, 194),
-	NewVector2Int(83, 84),
-	NewVector2Int(282, 293),
-	NewVector2Int(302, 271),
-	NewVector2Int(120, 231),
-	NewVector2Int(434, 427),
-	NewVector2Int(320, 405),
-	NewVector2Int(426, 423),
-	NewVector2Int(441, 286),
-	NewVector2Int(145, 144),
-	NewVector2Int(341, 464),
-	NewVector2Int(208, 200),
-	NewVector2Int(250, 462),
-	NewVector2Int(112, 232),
-	NewVector2Int(258, 286),
-	NewVector2Int(418, 424),
-	NewVector2Int(160, 159),
-	NewVector2Int(286, 384),
-	NewVector2Int(421, 200),
-	NewVector2Int(274, 1),
-	NewVector2Int(200, 201),
-	NewVector2Int(166, 59),
-	NewVector2Int(111, 123),
-	NewVector2Int(211, 210),
-	NewVector2Int(330, 347),
-	NewVector2Int(422, 430),
-	NewVector2Int(214, 135),
-	NewVector2Int(280, 346),
-	NewVector2Int(107, 9),
-	NewVector2Int(265, 446),
-	NewVector2Int(244, 243),
-	NewVector2Int(417, 351),
-	NewVector2Int(110, 163),
-	NewVector2Int(450, 348),
-	NewVector2Int(396, 377),
-	NewVector2Int(245, 244),
-	NewVector2Int(83, 182),
-	NewVector2Int(8, 417),
-	NewVector2Int(451, 349),
-	NewVector2Int(203, 98),
-	NewVector2Int(376, 435),
-	NewVector2Int(101, 50),
-	NewVector2Int(345, 447),
-	NewVector2Int(412, 357),
-	NewVector2Int(238, 241),
-	NewVector2Int(90, 77),
-	NewVector2Int(369, 395),
-	NewVector2Int(276, 353),
-	NewVector2Int(253, 449),
-	NewVector2Int(359, 467),
-	NewVector2Int(279, 358),
-	NewVector2Int(315, 15),
-	NewVector2Int(433, 416),
-	NewVector2Int(299, 336),
-	NewVector2Int(46, 156),
-	NewVector2Int(167, 37),
-	NewVector2Int(460, 290),
-	NewVector2Int(311, 310),
-	NewVector2Int(410, 432),
-	NewVector2Int(357, 277),
-	NewVector2Int(35, 226),
-	NewVector2Int(304, 271),
-	NewVector2Int(189, 55),
-	NewVector2Int(314, 315),
-	NewVector2Int(108, 67),
-	NewVector2Int(1, 4),
-	NewVector2Int(4, 275),
-	NewVector2Int(205, 36),
-	NewVector2Int(156, 143),
-	NewVector2Int(436, 426),
-	NewVector2Int(223, 53),
-	NewVector2Int(461, 370),
-	NewVector2Int(23, 144),
-	NewVector2Int(29, 224),
-	NewVector2Int(244, 112),
-	NewVector2Int(348, 349),
-	NewVector2Int(340, 345),
-	NewVector2Int(122, 6),
-	NewVector2Int(401, 361),
-	NewVector2Int(228, 24),
-	NewVector2Int(252, 381),
-	NewVector2Int(37, 164),
-	NewVector2Int(192, 187),
-	NewVector2Int(177, 58),
-	NewVector2Int(410, 409),
-	NewVector2Int(316, 402),
-	NewVector2Int(381, 382),
-	NewVector2Int(429, 279),
-	NewVector2Int(140, 176),
-	NewVector2Int(26, 154),
-	NewVector2Int(434, 394),
-	NewVector2Int(52, 63),
-	NewVector2Int(79, 166),
-	NewVector2Int(72, 41),
-	NewVector2Int(71, 162),
-	NewVector2Int(244, 233),
-	NewVector2Int(222, 65),
-	NewVector2Int(214, 210),
-	NewVector2Int(354, 370),
-	NewVector2Int(140, 32),
-	NewVector2Int(30, 160),
-	NewVector2Int(212, 57),
-	NewVector2Int(444, 443),
-	NewVector2Int(7, 33),
-	NewVector2Int(301, 251),
-	NewVector2Int(213, 138),
-	NewVector2Int(170, 176),
-	NewVector2Int(17, 83),
-	NewVector2Int(268, 302),
-	NewVector2Int(32, 211),
-	NewVector2Int(331, 358),
-	NewVector2Int(186, 40),
-	NewVector2Int(105, 52),
-	NewVector2Int(457, 354),
-	NewVector2Int(395, 379),
-	NewVector2Int(375, 321),
-	NewVector2Int(325, 320),
-	NewVector2Int(317, 316),
-	NewVector2Int(274, 354),
-	NewVector2Int(96, 88),
-	NewVector2Int(15, 14),
-	NewVector2Int(467, 260),
-	NewVector2Int(232, 128),
-	NewVector2Int(404, 320),
-	NewVector2Int(350, 349),
-	NewVector2Int(342, 265),
-	NewVector2Int(74, 185),
-	NewVector2Int(314, 17),
-	NewVector2Int(146, 61),
-	NewVector2Int(247, 113),
-	NewVector2Int(405, 321),
-	NewVector2Int(256, 341),
-	NewVector2Int(283, 444),
-	NewVector2Int(409, 291),
-	NewVector2Int(74, 41),
-	NewVector2Int(78, 191),
-	NewVector2Int(245, 188),
-	NewVector2Int(103, 104),
-	NewVector2Int(19, 370),
-	NewVector2Int(19, 141),
-	NewVector2Int(280, 411),
-	NewVector2Int(220, 131),
-	NewVector2Int(85, 86),
-	NewVector2Int(372, 265),
-	NewVector2Int(237, 220),
-	NewVector2Int(204, 43),
-	NewVector2Int(118, 119),
-	NewVector2Int(210, 202),
-	NewVector2Int(290, 460),
-	NewVector2Int(450, 449),
-	NewVector2Int(83, 18),
-	NewVector2Int(197, 3),
-	NewVector2Int(315, 404),
-	NewVector2Int(461, 458),
-	NewVector2Int(169, 170),
-	NewVector2Int(246, 161),
-	NewVector2Int(32, 201),
-	NewVector2Int(236, 196),
-	NewVector2Int(409, 304),
-	NewVector2Int(295, 282),
-	NewVector2Int(395, 369),
-	NewVector2Int(181, 180),
-	NewVector2Int(353, 372),
-	NewVector2Int(139, 34),
-	NewVector2Int(432, 436),
-	NewVector2Int(370, 461),
-	NewVector2Int(184, 42),
-	NewVector2Int(96, 78),
-	NewVector2Int(113, 247),
-	NewVector2Int(326, 391),
-	NewVector2Int(147, 123),
-	NewVector2Int(126, 100),
-	NewVector2Int(205, 101),
-	NewVector2Int(452, 350),
-	NewVector2Int(71, 21),
-	NewVector2Int(16, 85),
-	NewVector2Int(297, 333),
-	NewVector2Int(136, 150),
-	NewVector2Int(260, 445),
-	NewVector2Int(34, 139),
-	NewVector2Int(46, 113),
-	NewVector2Int(17, 315),
-	NewVector2Int(104, 54),
-	NewVector2Int(383, 372),
-	NewVector2Int(417, 285),
-	NewVector2Int(150, 170),
-	NewVector2Int(20, 60),
-	NewVector2Int(39, 73),
-	NewVector2Int(45, 4),
-	NewVector2Int(438, 344),
-	NewVector2Int(37, 0),
-	NewVector2Int(177, 123),
-	NewVector2Int(336, 296),
-	NewVector2Int(102, 129),
-	NewVector2Int(134, 198),
-	NewVector2Int(175, 199),
-	NewVector2Int(302, 303),
-	NewVector2Int(293, 334),
-	NewVector2Int(87, 86),
-	NewVector2Int(99, 240),
-	NewVector2Int(240, 99),
-	NewVector2Int(364, 394),
-	NewVector2Int(64, 102),
-	NewVector2Int(256, 452),
-	NewVector2Int(337, 297),
-	NewVector2Int(383, 264),
-	NewVector2Int(141, 242),
-	NewVector2Int(188, 174),
-	NewVector2Int(120, 119),
-	NewVector2Int(207, 187),
-	NewVector2Int(467, 263),
-	NewVector2Int(30, 225),
-	NewVector2Int(131, 48),
-	NewVector2Int(425, 266),
-	NewVector2Int(121, 120),
-	NewVector2Int(85, 17),
-	NewVector2Int(28, 157),
-	NewVector2Int(337, 9),
-	NewVector2Int(342, 353),
-	NewVector2Int(154, 153),
-	NewVector2Int(361, 366),
-	NewVector2Int(227, 137),
-	NewVector2Int(52, 105),
-	NewVector2Int(118, 50),
-	NewVector2Int(217, 209),
-	NewVector2Int(424, 422),
-	NewVector2Int(249, 390),
-	NewVector2Int(196, 6),
-	NewVector2Int(26, 232),
-	NewVector2Int(287, 273),
-	NewVector2Int(210, 169),
-	NewVector2Int(350, 329),
-	NewVector2Int(62, 191),
-	NewVector2Int(276, 300),
-	NewVector2Int(248, 281),
-	NewVector2Int(368, 383),
-	NewVector2Int(427, 432),
-	NewVector2Int(453, 341),
-	NewVector2Int(372, 353),
-	NewVector2Int(357, 453),
-	NewVector2Int(101, 118),
-	NewVector2Int(321, 307),
-	NewVector2Int(407, 310),
-	NewVector2Int(266, 371),
-	NewVector2Int(420, 429),
-	NewVector2Int(306, 407),
-	NewVector2Int(253, 252),
-	NewVector2Int(224, 225),
-	NewVector2Int(112, 133),
-	NewVector2Int(356, 264),
-	NewVector2Int(370, 19),
-	NewVector2Int(254, 253),
-	NewVector2Int(219, 115),
-	NewVector2Int(225, 46),
-	NewVector2Int(124, 143),
-	NewVector2Int(289, 439),
-	NewVector2Int(13, 312),
-	NewVector2Int(253, 373),
-	NewVector2Int(154, 22),
-	NewVector2Int(319, 318),
-	NewVector2Int(363, 281),
-	NewVector2Int(169, 150),
-	NewVector2Int(355, 277),
-	NewVector2Int(354, 461),
-	NewVector2Int(267, 164),
-	NewVector2Int(320, 319),
-	NewVector2Int(39, 40),
-	NewVector2Int(277, 357),
-	NewVector2Int(63, 105),
-	NewVector2Int(189, 244),
-	NewVector2Int(34, 227),
-	NewVector2Int(120, 230),
-	NewVector2Int(293, 301),
-	NewVector2Int(60, 99),
-	NewVector2Int(72, 73),
-	NewVector2Int(283, 293),
-	NewVector2Int(307, 321),
-	NewVector2Int(321, 405),
-	NewVector2Int(93, 132),
-	NewVector2Int(183, 62),
-	NewVector2Int(408, 409),
-	NewVector2Int(151, 338),
-	NewVector2Int(186, 216),
-	NewVector2Int(151, 109),
-	NewVector2Int(245, 122),
-	NewVector2Int(189, 221),
-	NewVector2Int(335, 424),
-	NewVector2Int(442, 258),
-	NewVector2Int(441, 442),
-	NewVector2Int(114, 47),
-	NewVector2Int(173, 133),
-	NewVector2Int(452, 451),
-	NewVector2Int(106, 43),
-	NewVector2Int(305, 289),
-	NewVector2Int(362, 414),
-	NewVector2Int(443, 259),
-	NewVector2Int(294, 279),
-	NewVector2Int(258, 442),
-	NewVector2Int(57, 43),
-	NewVector2Int(256, 252),
-	NewVector2Int(259, 443),
-	NewVector2Int(46, 70),
-	NewVector2Int(420, 360),
-	NewVector2Int(126, 142),
-	NewVector2Int(371, 355),
-	NewVector2Int(112, 244),
-	NewVector2Int(431, 369),
-	NewVector2Int(47, 114),
-	NewVector2Int(100, 126),
-	NewVector2Int(12, 13),
-	NewVector2Int(91, 77),
-	NewVector2Int(23, 22),
-	NewVector2Int(51, 4),
-	NewVector2Int(48, 115),
-	NewVector2Int(254, 449),
-	NewVector2Int(160, 27),
-	NewVector2Int(353, 342),
-	NewVector2Int(434, 416),
-	NewVector2Int(266, 423),
-	NewVector2Int(4, 274),
-	NewVector2Int(316, 15),
-	NewVector2Int(4, 45),
-	NewVector2Int(24, 23),
-	NewVector2Int(80, 183),
-	NewVector2Int(341, 453),
-	NewVector2Int(109, 67),
-	NewVector2Int(174, 196),
-	NewVector2Int(66, 105),
-	NewVector2Int(89, 88),
-	NewVector2Int(29, 223),
-	NewVector2Int(164, 2),
-	NewVector2Int(462, 370),
-	NewVector2Int(328, 290),
-	NewVector2Int(400, 395),
-	NewVector2Int(181, 91),
-	NewVector2Int(252, 380),
-	NewVector2Int(24, 144),
-	NewVector2Int(313, 18),
-	NewVector2Int(354, 19),
-	NewVector2Int(185, 61),
-	NewVector2Int(90, 89),
-	NewVector2Int(116, 227),
-	NewVector2Int(168, 417),
-	NewVector2Int(329, 371),
-	NewVector2Int(156, 34),
-	NewVector2Int(450, 252),
-	NewVector2Int(8, 285),
-	NewVector2Int(164, 267),
-	NewVector2Int(168, 8),
-	NewVector2Int(153, 145),
-	NewVector2Int(361, 323),
-	NewVector2Int(285, 413),
-	NewVector2Int(346, 280),
-	NewVector2Int(117, 228),
-	NewVector2Int(130, 247),
-	NewVector2Int(140, 211),
-	NewVector2Int(426, 425),
-	NewVector2Int(53, 63),
-	NewVector2Int(245, 233),
-	NewVector2Int(2, 97),
-	NewVector2Int(216, 206),
-	NewVector2Int(195, 3),
-	NewVector2Int(459, 458),
-	NewVector2Int(178, 179),
-	NewVector2Int(276, 342),
-	NewVector2Int(235, 75),
-	NewVector2Int(399, 412),
-	NewVector2Int(185, 74),
-	NewVector2Int(170, 211),
-	NewVector2Int(465, 357),
-	NewVector2Int(222, 221),
-	NewVector2Int(75, 59),
-	NewVector2Int(229, 117),
-	NewVector2Int(422, 432),
-	NewVector2Int(327, 423),
-	NewVector2Int(275, 4),
-	NewVector2Int(12, 268),
-	NewVector2Int(267, 0),
-	NewVector2Int(64, 49),
-	NewVector2Int(244, 245),
-	NewVector2Int(115, 131),
-	NewVector2Int(376, 401),
-	NewVector2Int(171, 32),
-	NewVector2Int(161, 247),
-	NewVector2Int(26, 22),
-	NewVector2Int(454, 356),
-	NewVector2Int(166, 218),
-	NewVector2Int(420, 363),
-	NewVector2Int(233, 128),
-	NewVector2Int(193, 55),
-	NewVector2Int(278, 279),
-	NewVector2Int(405, 320),
-	NewVector2Int(334, 296),
-	NewVector2Int(279, 360),
-	NewVector2Int(201, 32),
-	NewVector2Int(220, 45),
-	NewVector2Int(315, 17),
-	NewVector2Int(354, 274),
-	NewVector2Int(167, 39),
-	NewVector2Int(51, 236),
-	NewVector2Int(3, 196),
-	NewVector2Int(108, 69),
-	NewVector2Int(128, 47),
-	NewVector2Int(266, 426),
-	NewVector2Int(99, 20),
-	NewVector2Int(259, 387),
-	NewVector2Int(137, 132),
-	NewVector2Int(273, 321),
-	NewVector2Int(394, 431),
-	NewVector2Int(285, 295),
-	NewVector2Int(437, 429),
-	NewVector2Int(83, 17),
-	NewVector2Int(315, 403),
-	NewVector2Int(449, 254),
-	NewVector2Int(461, 457),
-	NewVector2Int(260, 467),
-	NewVector2Int(359, 446),
-	NewVector2Int(184, 185),
-	NewVector2Int(411, 376),
-	NewVector2Int(263, 249),
-	NewVector2Int(462, 458),
-	NewVector2Int(215, 172),
-	NewVector2Int(52, 65),
-	NewVector2Int(247, 161),
-	NewVector2Int(191, 80),
-	NewVector2Int(424, 431),
-	NewVector2Int(36, 206),
-	NewVector2Int(262, 369),
-	NewVector2Int(296, 282),
-	NewVector2Int(396, 369),
-	NewVector2Int(134, 220),
-	NewVector2Int(335, 273),
-	NewVector2Int(214, 212),
-	NewVector2Int(193, 189),
-	NewVector2Int(364, 416),
-	NewVector2Int(270, 409),
-	NewVector2Int(444, 445),
-	NewVector2Int(229, 228),
-	NewVector2Int(330, 280),
-	NewVector2Int(456, 419),
-	NewVector2Int(260, 444),
-	NewVector2Int(188, 196),
-	NewVector2Int(406, 418),
-	NewVector2Int(30, 247),
-	NewVector2Int(398, 414),
-	NewVector2Int(17, 314),
-	NewVector2Int(210, 214),
-	NewVector2Int(17, 85),
-	NewVector2Int(423, 358),
-	NewVector2Int(63, 52),
-	NewVector2Int(19, 274),
-	NewVector2Int(272, 311),
-	NewVector2Int(50, 118),
-	NewVector2Int(14, 15),
-	NewVector2Int(180, 84),
-	NewVector2Int(5, 195),
-	NewVector2Int(240, 98),
-	NewVector2Int(439, 344),
-	NewVector2Int(256, 451),
-	NewVector2Int(123, 187),
-	NewVector2Int(15, 16),
-	NewVector2Int(96, 90),
-	NewVector2Int(141, 241),
-	NewVector2Int(6, 196),
-	NewVector2Int(360, 279),
-	NewVector2Int(347, 346),
-	NewVector2Int(198, 217),
-	NewVector2Int(318, 319),
-	NewVector2Int(358, 355),
-	NewVector2Int(418, 406),
-	NewVector2Int(115, 219),
-	NewVector2Int(190, 133),
-	NewVector2Int(77, 76),
-	NewVector2Int(110, 24),
-	NewVector2Int(48, 49),
-	NewVector2Int(80, 81),
-	NewVector2Int(333, 298),
-	NewVector2Int(353, 276),
-	NewVector2Int(141, 97),
-	NewVector2Int(373, 254),
-	NewVector2Int(69, 108),
-	NewVector2Int(418, 262),
-	NewVector2Int(463, 413),
-	NewVector2Int(294, 455),
-	NewVector2Int(21, 68),
-	NewVector2Int(81, 82),
-	NewVector2Int(118, 229),
-	NewVector2Int(284, 298),
-	NewVector2Int(371, 266),
-	NewVector2Int(384, 385),
-	NewVector2Int(231, 26),
-	NewVector2Int(395, 394),
-	NewVector2Int(322, 410),
-	NewVector2Int(248, 195),
-	NewVector2Int(361, 401),
-	NewVector2Int(214, 202),
-	NewVector2Int(119, 230),
-	NewVector2Int(111, 226),
-	NewVector2Int(43, 91),
-	NewVector2Int(383, 276),
-	NewVector2Int(168, 351),
-	NewVector2Int(449, 450),
-	NewVector2Int(314, 405),
-	NewVector2Int(55, 65),
-	NewVector2Int(182, 106),
-	NewVector2Int(394, 434),
-	NewVector2Int(393, 326),
-	NewVector2Int(210, 204),
-	NewVector2Int(211, 169),
-	NewVector2Int(450, 451),
-	NewVector2Int(203, 165),
-	NewVector2Int(22, 153),
-	NewVector2Int(143, 34),
-	NewVector2Int(409, 270),
-	NewVector2Int(7, 110),
-	NewVector2Int(448, 261),
-	NewVector2Int(213, 215),
-	NewVector2Int(371, 423),
-	NewVector2Int(64, 235),
-	NewVector2Int(9, 336),
-	NewVector2Int(278, 344),
-	NewVector2Int(89, 179),
-	NewVector2Int(236, 198),
-	NewVector2Int(336, 285),
-	NewVector2Int(181, 182),
-	NewVector2Int(194, 201),
-	NewVector2Int(66, 52),
-	NewVector2Int(151, 10),
-	NewVector2Int(123, 177),
-	NewVector2Int(341, 256),
-	NewVector2Int(455, 278),
-	NewVector2Int(326, 393),
-	NewVector2Int(257, 442),
-	NewVector2Int(269, 267),
-	NewVector2Int(203, 142),
-	NewVector2Int(238, 20),
-	NewVector2Int(268, 271),
-	NewVector2Int(254, 373),
-	NewVector2Int(414, 362),
-	NewVector2Int(305, 455),
-	NewVector2Int(357, 465),
-	NewVector2Int(189, 243),
-	NewVector2Int(42, 81),
-	NewVector2Int(286, 441),
-	NewVector2Int(179, 86),
-	NewVector2Int(217, 198),
-	NewVector2Int(84, 83),
-	NewVector2Int(456, 363),
-	NewVector2Int(214, 192),
-	NewVector2Int(326, 370),
-	NewVector2Int(107, 66),
-	NewVector2Int(247, 226),
-	NewVector2Int(88, 89),
-	NewVector2Int(27, 222),
-	NewVector2Int(120, 121),
-	NewVector2Int(99, 98),
-	NewVector2Int(279, 294),
-	NewVector2Int(151, 108),
-	NewVector2Int(75, 235),
-	NewVector2Int(150, 149),
-	NewVector2Int(388, 466),
-	NewVector2Int(129, 126),
-	NewVector2Int(447, 372),
-	NewVector2Int(366, 447),
-	NewVector2Int(70, 156),
-	NewVector2Int(56, 221),
-	NewVector2Int(171, 208),
-	NewVector2Int(190, 221),
-	NewVector2Int(28, 159),
-	NewVector2Int(435, 397),
-	NewVector2Int(54, 68),
-	NewVector2Int(368, 264),
-	NewVector2Int(214, 169),
-	NewVector2Int(294, 278),
-	NewVector2Int(221, 65),
-	NewVector2Int(206, 165),
-	NewVector2Int(29, 160),
-	NewVector2Int(281, 195),
-	NewVector2Int(33, 130),
-	NewVector2Int(447, 264),
-	NewVector2Int(298, 284),
-	NewVector2Int(278, 455),
-	NewVector2Int(201, 208),
-	NewVector2Int(427, 434),
-	NewVector2Int(360, 344),
-	NewVector2Int(321, 273),
-	NewVector2Int(379, 394),
-	NewVector2Int(265, 372),
-	NewVector2Int(274, 457),
-	NewVector2Int(101, 120),
-	NewVector2Int(182, 194),
-	NewVector2Int(227, 116),
-	NewVector2Int(253, 254),
-	NewVector2Int(352, 376),
-	NewVector2Int(4, 44),
-	NewVector2Int(351, 417),
-	NewVector2Int(350, 452),
-	NewVector2Int(283, 282),
-	NewVector2Int(194, 204),
-	NewVector2Int(55, 9),
-	NewVector2Int(106, 91),
-	NewVector2Int(410, 322),
-	NewVector2Int(48, 235),
-	NewVector2Int(316, 315),
-	NewVector2Int(367, 397),
-	NewVector2Int(427, 411),
-	NewVector2Int(319, 320),
-	NewVector2Int(193, 244),
-	NewVector2Int(398, 384),
-	NewVector2Int(331, 294),
-	NewVector2Int(313, 17),
-	NewVector2Int(297, 338),
-	NewVector2Int(164, 37),
-	NewVector2Int(403, 319),
-	NewVector2Int(349, 348),
-	NewVector2Int(47, 126),
-	NewVector2Int(8, 55),
-	NewVector2Int(147, 213),
-	NewVector2Int(320, 321),
-	NewVector2Int(227, 93),
-	NewVector2Int(134, 131),
-	NewVector2Int(44, 237),
-	NewVector2Int(393, 391),
-	NewVector2Int(439, 278),
-	NewVector2Int(451, 252),
-	NewVector2Int(120, 232),
-	NewVector2Int(287, 409),
-	NewVector2Int(221, 55),
-	NewVector2Int(385, 386),
-	NewVector2Int(347, 280),
-	NewVector2Int(57, 212),
-	NewVector2Int(58, 177),
-	NewVector2Int(257, 386),
-	NewVector2Int(216, 205),
-	NewVector2Int(208, 201),
-	NewVector2Int(386, 387),
-	NewVector2Int(459, 457),
-	NewVector2Int(137, 177),
-	NewVector2Int(391, 322),
-	NewVector2Int(49, 64),
-	NewVector2Int(55, 107),
-	NewVector2Int(308, 324),
-	NewVector2Int(372, 345),
-	NewVector2Int(11, 37),
-	NewVector2Int(431, 394),
-	NewVector2Int(171, 175),
-	NewVector2Int(330, 348),
-	NewVector2Int(156, 46),
-	NewVector2Int(11, 302),
-	NewVector2Int(280, 347),
-	NewVector2Int(452, 453),
-	NewVector2Int(352, 366),
-	NewVector2Int(12, 38),
-	NewVector2Int(64, 48),
-	NewVector2Int(22, 231),
-	NewVector2Int(414, 463),
-	NewVector2Int(448, 339),
-	NewVector2Int(422, 287),
-	NewVector2Int(59, 235),
-	NewVector2Int(13, 268),
-	NewVector2Int(298, 251),
-	NewVector2Int(237, 241),
-	NewVector2Int(68, 54),
-	NewVector2Int(120, 101),
-	NewVector2Int(92, 39),
-	NewVector2Int(43, 146),
-	NewVector2Int(377, 400),
-	NewVector2Int(238, 242),
-	NewVector2Int(91, 43),
-	NewVector2Int(369, 396),
-	NewVector2Int(301, 298),
-	NewVector2Int(286, 398),
-	NewVector2Int(346, 449),
-	NewVector2Int(220, 44),
-	NewVector2Int(299, 337),
-	NewVector2Int(358, 423),
-	NewVector2Int(131, 115),
-	NewVector2Int(3, 195),
-	NewVector2Int(23, 24),
-	NewVector2Int(416, 364),
-	NewVector2Int(123, 111),
-	NewVector2Int(160, 29),
-	NewVector2Int(326, 327),
-	NewVector2Int(266, 425),
-	NewVector2Int(345, 340),
-	NewVector2Int(53, 52),
-	NewVector2Int(98, 203),
-	NewVector2Int(411, 434),
-	NewVector2Int(358, 279),
-	NewVector2Int(3, 51),
-	NewVector2Int(261, 346),
-	NewVector2Int(367, 364),
-	NewVector2Int(66, 107),
-	NewVector2Int(86, 85),
-	NewVector2Int(445, 283),
-	NewVector2Int(24, 110),
-	NewVector2Int(23, 145),
-	NewVector2Int(89, 90),
-	NewVector2Int(449, 253),
-	NewVector2Int(340, 346),
-	NewVector2Int(235, 64),
-	NewVector2Int(67, 108),
-	NewVector2Int(228, 25),
-	NewVector2Int(119, 118),
-	NewVector2Int(250, 309),
-	NewVector2Int(90, 91),
-	NewVector2Int(269, 322),
-	NewVector2Int(264, 356),
-	NewVector2Int(84, 17),
-	NewVector2Int(316, 403),
-	NewVector2Int(407, 292),
-	NewVector2Int(426, 391),
-	NewVector2Int(399, 437),
-	NewVector2Int(212, 202),
-	NewVector2Int(175, 171),
-	NewVector2Int(117, 50),
-	NewVector2Int(374, 380),
-	NewVector2Int(165, 206),
-	NewVector2Int(205, 50),
-	NewVector2Int(185, 184),
-	NewVector2Int(30, 161),
-	NewVector2Int(445, 260),
-	NewVector2Int(184, 76),
-	NewVector2Int(195, 5),
-	NewVector2Int(186, 185),
-	NewVector2Int(446, 261),
-	NewVector2Int(357, 412),
-	NewVector2Int(392, 290),
-	NewVector2Int(225, 113),
-	NewVector2Int(17, 84),
-	NewVector2Int(189, 190),
-	NewVector2Int(384, 286),
-	NewVector2Int(329, 350),
-	NewVector2Int(429, 437),
-	NewVector2Int(241, 237),
-	NewVector2Int(19, 44),
-	NewVector2Int(165, 98),
-	NewVector2Int(219, 218),
-	NewVector2Int(4, 1),
-	NewVector2Int(50, 117),
-	NewVector2Int(222, 223),
-	NewVector2Int(422, 434),
-	NewVector2Int(175, 148),
-	NewVector2Int(198, 131),
-	NewVector2Int(204, 211),
-	NewVector2Int(68, 21),
-	NewVector2Int(381, 252),
-	NewVector2Int(462, 326),
-	NewVector2Int(60, 166),
-	NewVector2Int(174, 188),
-	NewVector2Int(414, 286),
-	NewVector2Int(339, 255),
-	NewVector2Int(115, 218),
-	NewVector2Int(411, 280),
-	NewVector2Int(181, 83),
-	NewVector2Int(401, 352),
-	NewVector2Int(333, 297),
-	NewVector2Int(46, 124),
-	NewVector2Int(373, 253),
-	NewVector2Int(71, 68),
-	NewVector2Int(424, 335),
-	NewVector2Int(76, 146),
-	NewVector2Int(49, 129),
-	NewVector2Int(69, 107),
-	NewVector2Int(335, 406),
-	NewVector2Int(148, 171),
-	NewVector2Int(460, 294),
-	NewVector2Int(192, 214),
-	NewVector2Int(446, 359),
-	NewVector2Int(391, 423),
-	NewVector2Int(105, 66),
-	NewVector2Int(410, 436),
-	NewVector2Int(110, 144),
-	NewVector2Int(249, 339),
-	NewVector2Int(163, 7),
-	NewVector2Int(273, 287),
-	NewVector2Int(45, 51),
-	NewVector2Int(225, 247),
-	NewVector2Int(373, 374),
-	NewVector2Int(38, 12),
-	NewVector2Int(263, 359),
-	NewVector2Int(173, 56),
-	NewVector2Int(159, 158),
-	NewVector2Int(461, 459),
-	NewVector2Int(111, 117),
-	NewVector2Int(211, 204),
-	NewVector2Int(225, 224),
-	NewVector2Int(151, 9),
-	NewVector2Int(370, 462),
-	NewVector2Int(55, 221),
-	NewVector2Int(156, 124),
-	NewVector2Int(196, 197),
-	NewVector2Int(47, 217),
-	NewVector2Int(285, 9),
-	NewVector2Int(208, 171),
-	NewVector2Int(280, 425),
-	NewVector2Int(164, 393),
-	NewVector2Int(258, 257),
-	NewVector2Int(229, 230),
-	NewVector2Int(389, 368),
-	NewVector2Int(139, 156),
-	NewVector2Int(353, 265),
-	NewVector2Int(412, 351),
-	NewVector2Int(218, 220),
-	NewVector2Int(304, 409),
-	NewVector2Int(311, 268),
-	NewVector2Int(370, 354),
-	NewVector2Int(288, 401),
-	NewVector2Int(179, 85),
-	NewVector2Int(159, 27),
-	NewVector2Int(190, 243),
-	NewVector2Int(196, 174),
-	NewVector2Int(295, 296),
-	NewVector2Int(142, 203),
-	NewVector2Int(173, 190),
-	NewVector2Int(193, 168),
-	NewVector2Int(325, 324),
-	NewVector2Int(425, 411),
-	NewVector2Int(391, 269),
-	NewVector2Int(99, 97),
-	NewVector2Int(347, 348),
-	NewVector2Int(366, 361),
-	NewVector2Int(2, 164),
-	NewVector2Int(131, 49),
-	NewVector2Int(26, 112),
-	NewVector2Int(380, 381),
-	NewVector2Int(211, 194),
-	NewVector2Int(104, 68),
-	NewVector2Int(124, 46),
-	NewVector2Int(234, 34),
-	NewVector2Int(413, 414),
-	NewVector2Int(432, 427),
-	NewVector2Int(452, 256),
-	NewVector2Int(177, 137),
-	NewVector2Int(43, 57),
-	NewVector2Int(276, 445),
-	NewVector2Int(248, 197),
-	NewVector2Int(209, 126),
-	NewVector2Int(142, 36),
-	NewVector2Int(226, 35),
-	NewVector2Int(2, 141),
-	NewVector2Int(237, 44),
-	NewVector2Int(435, 288),
-	NewVector2Int(129, 102),
-	NewVector2Int(273, 375),
-	NewVector2Int(166, 20),
-	NewVector2Int(425, 280),
-	NewVector2Int(77, 91),
-	NewVector2Int(461, 462),
-	NewVector2Int(306, 408),
-	NewVector2Int(247, 130),
-	NewVector2Int(206, 92),
-	NewVector2Int(193, 122),
-	NewVector2Int(200, 18),
-	NewVector2Int(157, 56),
-	NewVector2Int(352, 411),
-	NewVector2Int(46, 225),
-	NewVector2Int(345, 372),
-	NewVector2Int(264, 447),
-	NewVector2Int(436, 410),
-	NewVector2Int(17, 18),
-	NewVector2Int(28, 27),
-	NewVector2Int(235, 240),
-	NewVector2Int(128, 114),
-	NewVector2Int(134, 45),
-	NewVector2Int(198, 209),
-	NewVector2Int(64, 129),
-	NewVector2Int(459,
-		250),
-	NewVector2Int(192, 135),
-	NewVector2Int(112, 26),
-	NewVector2Int(404, 314),
-	NewVector2Int(297, 337),
-	NewVector2Int(428, 262),
-	NewVector2Int(81, 38),
-	NewVector2Int(51, 195),
-	NewVector2Int(311, 271),
-	NewVector2Int(442, 282),
-	NewVector2Int(179, 88),
-	NewVector2Int(252, 450),
-	NewVector2Int(191, 62),
-	NewVector2Int(84, 85),
-	NewVector2Int(203, 36),
-	NewVector2Int(443, 283),
-	NewVector2Int(121, 232),
-	NewVector2Int(321, 406),
-	NewVector2Int(226, 25),
-	NewVector2Int(323, 366),
-	NewVector2Int(117, 118),
-	NewVector2Int(122, 196),
-	NewVector2Int(191, 183),
-	NewVector2Int(397, 288),
-	NewVector2Int(383, 353),
-	NewVector2Int(161, 160),
-	NewVector2Int(25, 7),
-	NewVector2Int(11, 72),
-	NewVector2Int(239, 79),
-	NewVector2Int(305, 290),
-	NewVector2Int(464, 463),
-	NewVector2Int(22, 230),
-	NewVector2Int(183, 184),
-	NewVector2Int(143, 111),
-	NewVector2Int(442, 295),
-	NewVector2Int(241, 125),
-	NewVector2Int(12, 302),
-	NewVector2Int(426, 436),
-	NewVector2Int(13, 38),
-	NewVector2Int(428, 396),
-	NewVector2Int(127, 139),
-	NewVector2Int(120, 100),
-	NewVector2Int(259, 444),
-	NewVector2Int(378, 395),
-	NewVector2Int(183, 76),
-	NewVector2Int(260, 259),
-	NewVector2Int(427, 436),
-	NewVector2Int(346, 448),
-	NewVector2Int(306, 375),
-	NewVector2Int(255, 446),
-	NewVector2Int(36, 142),
-	NewVector2Int(369, 431),
-	NewVector2Int(51, 5),
-	NewVector2Int(435, 376),
-	NewVector2Int(282, 283),
-	NewVector2Int(462, 250),
-	NewVector2Int(347, 449),
-	NewVector2Int(205, 216),
-	NewVector2Int(64, 240),
-	NewVector2Int(267, 11),
-	NewVector2Int(351, 419),
-	NewVector2Int(431, 262),
-	NewVector2Int(411, 433),
-	NewVector2Int(143, 124),
-	NewVector2Int(190, 56),
-	NewVector2Int(315, 316),
-	NewVector2Int(346, 340),
-	NewVector2Int(116, 143),
-	NewVector2Int(316, 317),
-	NewVector2Int(327, 326),
-	NewVector2Int(206, 36),
-	NewVector2Int(192, 138),
-	NewVector2Int(464, 417),
-	NewVector2Int(149, 176),
-	NewVector2Int(356, 389),
-	NewVector2Int(349, 350),
-	NewVector2Int(102, 64),
-	NewVector2Int(47, 128),
-	NewVector2Int(81, 41),
-	NewVector2Int(147, 215),
-	NewVector2Int(450, 253),
-	NewVector2Int(25, 226),
-	NewVector2Int(467, 342),
-	NewVector2Int(332, 297),
-	NewVector2Int(464, 453),
-	NewVector2Int(270, 322),
-	NewVector2Int(117, 229),
-	NewVector2Int(282, 296),
-	NewVector2Int(129, 203),
-	NewVector2Int(155, 112),
-	NewVector2Int(434, 430),
-	NewVector2Int(345, 352),
-	NewVector2Int(465, 417),
-	NewVector2Int(248, 419),
-	NewVector2Int(61, 184),
-	NewVector2Int(216, 207),
-	NewVector2Int(307, 325),
-	NewVector2Int(202, 43),
-	NewVector2Int(313, 405),
-	NewVector2Int(79, 238),
-	NewVector2Int(39, 165),
-	NewVector2Int(177, 215),
-	NewVector2Int(392, 289),
-	NewVector2Int(329, 349),
-	NewVector2Int(201, 200),
-	NewVector2Int(87, 178),
-	NewVector2Int(448, 449),
-	NewVector2Int(172, 136),
-	NewVector2Int(266, 329),
-	NewVector2Int(274, 4),
-	NewVector2Int(165, 97),
-	NewVector2Int(226, 113),
-	NewVector2Int(56, 190),
-	NewVector2Int(323, 454),
-	NewVector2Int(264, 368),
-	NewVector2Int(229, 118),
-	NewVector2Int(75, 60),
-	NewVector2Int(88, 179),
-	NewVector2Int(415, 308),
-	NewVector2Int(293, 282),
-	NewVector2Int(212, 214),
-	NewVector2Int(204, 210),
-	NewVector2Int(230, 119),
-	NewVector2Int(176, 148),
-	NewVector2Int(188, 122),
-	NewVector2Int(339, 254),
-	NewVector2Int(42, 184),
-	NewVector2Int(342, 445),
-	NewVector2Int(199, 396),
-	NewVector2Int(166, 219),
-	NewVector2Int(419, 399),
-	NewVector2Int(30, 29),
-	NewVector2Int(15, 315),
-	NewVector2Int(236, 134),
-	NewVector2Int(301, 300),
-	NewVector2Int(333, 332),
-	NewVector2Int(352, 345),
-	NewVector2Int(52, 53),
-	NewVector2Int(166, 75),
-	NewVector2Int(192, 213),
-	NewVector2Int(3, 197),
-	NewVector2Int(77, 146),
-	NewVector2Int(363, 360),
-	NewVector2Int(334, 333),
-	NewVector2Int(6, 351),
-	NewVector2Int(6, 122),
-	NewVector2Int(406, 321),
-	NewVector2Int(284, 332),
-	NewVector2Int(350, 277),
-	NewVector2Int(8, 168),
-	NewVector2Int(129, 49),
-	NewVector2Int(0, 164),
-	NewVector2Int(300, 276),
-	NewVector2Int(86, 87),
-	NewVector2Int(226, 247),
-	NewVector2Int(119, 120),
-	NewVector2Int(37, 167),
-	NewVector2Int(111, 116),
-	NewVector2Int(451, 450),
-	NewVector2Int(335, 418),
-	NewVector2Int(40, 92),
-	NewVector2Int(52, 66),
-	NewVector2Int(285, 417),
-	NewVector2Int(224, 29),
-	NewVector2Int(330, 425),
-	NewVector2Int(285, 8),
-	NewVector2Int(236, 3),
-	NewVector2Int(307, 292),
-	NewVector2Int(187, 147),
-	NewVector2Int(139, 70),
-	NewVector2Int(185, 186),
-	NewVector2Int(270, 410),
-	NewVector2Int(456, 420),
-	NewVector2Int(407, 415),
-	NewVector2Int(294, 358),
-	NewVector2Int(78, 96),
-	NewVector2Int(259, 257),
-	NewVector2Int(218, 219),
-	NewVector2Int(417, 465),
-	NewVector2Int(304, 408),
-	NewVector2Int(56, 157),
-	NewVector2Int(63, 53),
-	NewVector2Int(349, 330),
-	NewVector2Int(241, 239),
-	NewVector2Int(261, 446),
-	NewVector2Int(281, 275),
-	NewVector2Int(396, 262),
-	NewVector2Int(441, 413),
-	NewVector2Int(327, 391),
-	NewVector2Int(100, 120),
-	NewVector2Int(346, 261),
-	NewVector2Int(348, 450),
-	NewVector2Int(340, 446),
-	NewVector2Int(180, 85),
-	NewVector2Int(252, 253),
-	NewVector2Int(137, 123),
-	NewVector2Int(349, 451),
-	NewVector2Int(462, 328),
-	NewVector2Int(455, 289),
-	NewVector2Int(6, 197),
-	NewVector2Int(377, 369),
-	NewVector2Int(115, 220),
-	NewVector2Int(35, 111),
-	NewVector2Int(383, 300),
-	NewVector2Int(110, 25),
-	NewVector2Int(437, 420),
-	NewVector2Int(333, 299),
-	NewVector2Int(252, 374),
-	NewVector2Int(31, 226),
-	NewVector2Int(71, 70),
-	NewVector2Int(104, 67),
-	NewVector2Int(49, 131),
-	NewVector2Int(463, 414),
-	NewVector2Int(118, 230),
-	NewVector2Int(104, 103),
-	NewVector2Int(155, 154),
-	NewVector2Int(220, 134),
-	NewVector2Int(262, 396),
-	NewVector2Int(70, 46),
-	NewVector2Int(436, 432),
-	NewVector2Int(167, 164),
-	NewVector2Int(170, 169),
-	NewVector2Int(211, 170),
-	NewVector2Int(77, 90),
-	NewVector2Int(22, 154),
-	NewVector2Int(237, 79),
-	NewVector2Int(197, 6),
-	NewVector2Int(143, 35),
-	NewVector2Int(146, 77),
-	NewVector2Int(434, 364),
-	NewVector2Int(72, 11),
-	NewVector2Int(9, 337),
-	NewVector2Int(465, 351),
-	NewVector2Int(89, 180),
-	NewVector2Int(255, 249),
-	NewVector2Int(295, 285),
-	NewVector2Int(90, 181),
-	NewVector2Int(91, 146),
-	NewVector2Int(143, 156),
-	NewVector2Int(18, 421),
-	NewVector2Int(232, 121),
-	NewVector2Int(425, 436),
-	NewVector2Int(257, 443),
-	NewVector2Int(29, 27),
-	NewVector2Int(327, 358),
-	NewVector2Int(433, 376),
-	NewVector2Int(299, 296),
-	NewVector2Int(259, 260),
-	NewVector2Int(405, 314),
-	NewVector2Int(158, 28),
-	NewVector2Int(217, 114),
-	NewVector2Int(197, 248),
-	NewVector2Int(124, 35),
-	NewVector2Int(375, 291),
-	NewVector2Int(321, 320),
-	NewVector2Int(324, 325),
-	NewVector2Int(336, 299),
-	NewVector2Int(62, 96),
-	NewVector2Int(22, 23),
-	NewVector2Int(281, 363),
-	NewVector2Int(443, 282),
-	NewVector2Int(20, 99),
-	NewVector2Int(124, 156),
-	NewVector2Int(464, 341),
-	NewVector2Int(27, 223),
-	NewVector2Int(125, 237),
-	NewVector2Int(148, 140),
-	NewVector2Int(279, 331),
-	NewVector2Int(32, 194),
-	NewVector2Int(44, 19),
-	NewVector2Int(244, 193),
-	NewVector2Int(439, 289),
-	NewVector2Int(453, 452),
-	NewVector2Int(12, 72),
-	NewVector2Int(343, 437),
-	NewVector2Int(94, 370),
-	NewVector2Int(94, 141),
-	NewVector2Int(429, 360),
-	NewVector2Int(444, 259),
-	NewVector2Int(322, 270),
-	NewVector2Int(121, 100),
-	NewVector2Int(199, 428),
-	NewVector2Int(379, 395),
-	NewVector2Int(32, 171),
-	NewVector2Int(322, 391),
-	NewVector2Int(389, 301),
-	NewVector2Int(279, 429),
-	NewVector2Int(394, 379),
-	NewVector2Int(453, 465),
-	NewVector2Int(380, 252),
-	NewVector2Int(416, 434),
-	NewVector2Int(362, 463),
-	NewVector2Int(309, 457),
-	NewVector2Int(6, 419),
-	NewVector2Int(193, 245),
-	NewVector2Int(413, 285),
-	NewVector2Int(310, 272),
-	NewVector2Int(163, 110),
-	NewVector2Int(29, 30),
-	NewVector2Int(69, 66),
-	NewVector2Int(114, 217),
-	NewVector2Int(328, 326),
-	NewVector2Int(431, 418),
-	NewVector2Int(98, 129),
-	NewVector2Int(171, 199),
-	NewVector2Int(303, 269),
-	NewVector2Int(91, 90),
-	NewVector2Int(156, 70),
-	NewVector2Int(282, 295),
-	NewVector2Int(287, 410),
-	NewVector2Int(73, 72),
-	NewVector2Int(194, 182),
-	NewVector2Int(408, 291),
-	NewVector2Int(221, 56),
-	NewVector2Int(126, 47),
-	NewVector2Int(137, 93),
-	NewVector2Int(49, 209),
-	NewVector2Int(257, 387),
-	NewVector2Int(416, 411),
-	NewVector2Int(176, 170),
-	NewVector2Int(95, 96),
-	NewVector2Int(235, 219),
-	NewVector2Int(401, 288),
-	NewVector2Int(346, 352),
-	NewVector2Int(79, 237),
-	NewVector2Int(218, 166),
-	NewVector2Int(430, 431),
-	NewVector2Int(289, 455),
-	NewVector2Int(82, 13),
-	NewVector2Int(108, 151),
-	NewVector2Int(355, 437),
-	NewVector2Int(342, 467),
-	NewVector2Int(276, 293),
-	NewVector2Int(308, 325),
-	NewVector2Int(427, 425),
-	NewVector2Int(97, 141),
-	NewVector2Int(431, 395),
-	NewVector2Int(423, 391),
-	NewVector2Int(190, 189),
-	NewVector2Int(330, 349),
-	NewVector2Int(162, 127),
-	NewVector2Int(359, 263),
-	NewVector2Int(464, 465),
-	NewVector2Int(376, 352),
-	NewVector2Int(223, 222),
-	NewVector2Int(435, 401),
-	NewVector2Int(230, 118),
-	NewVector2Int(222, 28),
-	NewVector2Int(408, 304),
-	NewVector2Int(50, 187),
-	NewVector2Int(42, 183),
-	NewVector2Int(183, 42),
-	NewVector2Int(187, 192),
-	NewVector2Int(25, 130),
-	NewVector2Int(265, 340),
-	NewVector2Int(92, 40),
-	NewVector2Int(371, 358),
-	NewVector2Int(211, 140),
-	NewVector2Int(35, 143),
-	NewVector2Int(15, 85),
-	NewVector2Int(142, 126),
-	NewVector2Int(195, 281),
-	NewVector2Int(440, 457),
-	NewVector2Int(221, 190),
-	NewVector2Int(386, 257),
-	NewVector2Int(277, 350),
-	NewVector2Int(251, 298),
-	NewVector2Int(383, 368),
-	NewVector2Int(255, 448),
-	NewVector2Int(290, 289),
-	NewVector2Int(317, 14),
-	NewVector2Int(103, 54),
-	NewVector2Int(414, 441),
-	NewVector2Int(160, 30),
-	NewVector2Int(174, 114),
-	NewVector2Int(326, 328),
-	NewVector2Int(460, 328),
-	NewVector2Int(152, 175),
-	NewVector2Int(124, 113),
-	NewVector2Int(367, 365),
-	NewVector2Int(98, 240),
-	NewVector2Int(64, 98),
-	NewVector2Int(262, 428),
-	NewVector2Int(396, 428),
-	NewVector2Int(122, 188),
-	NewVector2Int(327, 328),
-	NewVector2Int(80, 42),
-	NewVector2Int(265, 353),
-	NewVector2Int(38, 82),
-	NewVector2Int(282, 442),
-	NewVector2Int(209, 49),
-	NewVector2Int(73, 39),
-	NewVector2Int(25, 228),
-	NewVector2Int(302, 11),
-	NewVector2Int(100, 142),
-	NewVector2Int(447, 366),
-	NewVector2Int(460, 305),
-	NewVector2Int(451, 256),
-	NewVector2Int(1, 19),
-	NewVector2Int(421, 313),
-	NewVector2Int(370, 2),
-	NewVector2Int(434, 432),
-	NewVector2Int(321, 375),
-	NewVector2Int(308, 292),
-	NewVector2Int(179, 178),
-	NewVector2Int(39, 167),
-	NewVector2Int(459, 461),
-	NewVector2Int(46, 63),
-	NewVector2Int(391, 326),
-	NewVector2Int(417, 464),
-	NewVector2Int(197, 195),
-	NewVector2Int(230, 229),
-	NewVector2Int(356, 368),
-	NewVector2Int(349, 329),
-	NewVector2Int(241, 238),
-	NewVector2Int(326, 462),
-	NewVector2Int(52, 222),
-	NewVector2Int(401, 376),
-	NewVector2Int(172, 138),
-	NewVector2Int(245, 128),
-	NewVector2Int(288, 435),
-	NewVector2Int(382, 362),
-	NewVector2Int(407, 306),
-	NewVector2Int(187, 123),
-	NewVector2Int(292, 325),
-	NewVector2Int(212, 216),
-	NewVector2Int(411, 425),
-	NewVector2Int(143, 116),
-	NewVector2Int(349, 450),
-	NewVector2Int(216, 186),
-	NewVector2Int(296, 295),
-	NewVector2Int(18, 201),
-	NewVector2Int(247, 30),
-	NewVector2Int(262, 418),
-	NewVector2Int(14, 316),
-	NewVector2Int(14, 87),
-	NewVector2Int(114, 174),
-	NewVector2Int(195, 248),
-	NewVector2Int(300, 301),
-	NewVector2Int(162, 71),
-	NewVector2Int(181, 84),
-	NewVector2Int(423, 371),
-	NewVector2Int(369, 400),
-	NewVector2Int(330, 329),
-	NewVector2Int(142, 129),
-	NewVector2Int(188, 245),
-	NewVector2Int(333, 334),
-	NewVector2Int(334, 299),
-	NewVector2Int(274, 19),
-	NewVector2Int(251, 301),
-	NewVector2Int(264, 383),
-	NewVector2Int(76, 183),
-	NewVector2Int(355, 358),
-	NewVector2Int(434, 422),
-	NewVector2Int(286, 258),
-	NewVector2Int(372, 447),
-	NewVector2Int(31, 117),
-	NewVector2Int(438, 392),
-	NewVector2Int(38, 13),
-	NewVector2Int(1, 274),
-	NewVector2Int(168, 122),
-	NewVector2Int(121, 47),
-	NewVector2Int(7, 25),
-	NewVector2Int(138, 136),
-	NewVector2Int(262, 431),
-	NewVector2Int(97, 98),
-	NewVector2Int(369, 377),
-	NewVector2Int(463, 341),
-	NewVector2Int(401, 366),
-	NewVector2Int(146, 76),
-	NewVector2Int(9, 107),
-	NewVector2Int(451, 452),
-	NewVector2Int(238, 79),
-	NewVector2Int(462, 461),
-	NewVector2Int(132, 177),
-	NewVector2Int(441, 258),
-	NewVector2Int(339, 390),
-	NewVector2Int(204, 202),
-	NewVector2Int(90, 180),
-	NewVector2Int(410, 270),
-	NewVector2Int(355, 371),
-	NewVector2Int(176, 140),
-	NewVector2Int(27, 160),
-	NewVector2Int(447, 454),
-	NewVector2Int(114, 128),
-	NewVector2Int(466, 467),
-	NewVector2Int(188, 114),
-	NewVector2Int(141, 2),
-	NewVector2Int(91, 181),
-	NewVector2Int(78, 62),
-	NewVector2Int(232, 120),
-	NewVector2Int(48, 219),
-	NewVector2Int(400, 378),
-	NewVector2Int(207, 212),
-	NewVector2Int(406, 421),
-	NewVector2Int(199, 208),
-	NewVector2Int(439, 455),
-	NewVector2Int(250, 290),
-	NewVector2Int(405, 313),
-	NewVector2Int(269, 303),
-	NewVector2Int(295, 441),
-	NewVector2Int(36, 101),
-	NewVector2Int(261, 448),
-	NewVector2Int(388, 260),
-	NewVector2Int(20, 242),
-	NewVector2Int(414, 398),
-	NewVector2Int(273, 422),
-	NewVector2Int(433, 411),
-	NewVector2Int(4, 5),
-	NewVector2Int(159, 28),
-	NewVector2Int(255, 261),
-	NewVector2Int(175, 152),
-	NewVector2Int(117, 31),
-	NewVector2Int(99, 242),
-	NewVector2Int(133, 155),
-	NewVector2Int(53, 46),
-	NewVector2Int(66, 65),
-	NewVector2Int(406, 313),
-	NewVector2Int(322, 436),
-	NewVector2Int(337, 299),
-	NewVector2Int(134, 236),
-	NewVector2Int(456, 399),
-	NewVector2Int(328, 250),
-	NewVector2Int(247, 33),
-	NewVector2Int(365, 397),
-	NewVector2Int(70, 71),
-	NewVector2Int(338, 151),
-	NewVector2Int(35, 113),
-	NewVector2Int(94, 19),
-	NewVector2Int(194, 106),
-	NewVector2Int(447, 323),
-	NewVector2Int(31, 228),
-	NewVector2Int(185, 57),
-	NewVector2Int(63, 68),
-	NewVector2Int(104, 69),
-	NewVector2Int(43, 202),
-	NewVector2Int(217, 126),
-	NewVector2Int(21, 71),
-	NewVector2Int(454, 447),
-	NewVector2Int(140, 171),
-	NewVector2Int(398, 286),
-	NewVector2Int(245, 193),
-	NewVector2Int(56, 28),
-	NewVector2Int(196, 188),
-	NewVector2Int(435, 433),
-	NewVector2Int(54, 104),
-	NewVector2Int(368, 300),
-	NewVector2Int(273, 291),
-	NewVector2Int(92, 216),
-	NewVector2Int(255, 359),
-	NewVector2Int(437, 399),
-	NewVector2Int(309, 250),
-	NewVector2Int(322, 269),
-	NewVector2Int(113, 46),
-	NewVector2Int(59, 75),
-	NewVector2Int(146, 43),
-	NewVector2Int(358, 371),
-	NewVector2Int(362, 341),
-	NewVector2Int(148, 152),
-	NewVector2Int(200, 428),
-	NewVector2Int(140, 148),
-	NewVector2Int(221, 222),
-	NewVector2Int(200, 199),
-	NewVector2Int(240, 235),
-	NewVector2Int(272, 304),
-	NewVector2Int(232, 231),
-	NewVector2Int(421, 18),
-	NewVector2Int(446, 340),
-	NewVector2Int(55, 189),
-	NewVector2Int(169, 211),
-	NewVector2Int(287, 432),
-	NewVector2Int(228, 117),
-	NewVector2Int(233, 232),
-	NewVector2Int(125, 141),
-	NewVector2Int(453, 464),
-	NewVector2Int(310, 415),
-	NewVector2Int(289, 392),
-	NewVector2Int(416, 433),
-	NewVector2Int(322, 426),
-	NewVector2Int(294, 327),
-	NewVector2Int(118, 101),
-	NewVector2Int(199, 175),
-	NewVector2Int(438, 457),
-	NewVector2Int(366, 352),
-	NewVector2Int(404, 315),
-	NewVector2Int(269, 270),
-	NewVector2Int(367, 433),
-	NewVector2Int(298, 333),
-	NewVector2Int(311, 272),
-	NewVector2Int(0, 267),
-	NewVector2Int(252, 451),
-	NewVector2Int(179, 89),
-	NewVector2Int(25, 31),
-	NewVector2Int(278, 360),
-	NewVector2Int(269, 391),
-	NewVector2Int(325, 292),
-	NewVector2Int(413, 441),
-	NewVector2Int(107, 69),
-	NewVector2Int(92, 206),
-	NewVector2Int(365, 364),
-	NewVector2Int(363, 440),
-	NewVector2Int(62, 183),
-	NewVector2Int(187, 214),
-	NewVector2Int(455, 294),
-	NewVector2Int(133, 243),
-	NewVector2Int(387, 388),
-	NewVector2Int(219, 166),
-	NewVector2Int(350, 357),
-	NewVector2Int(109, 151),
-	NewVector2Int(448, 254),
-	NewVector2Int(419, 456),
-	NewVector2Int(431, 430),
-	NewVector2Int(50, 101),
-	NewVector2Int(423, 426),
-	NewVector2Int(222, 27),
-	NewVector2Int(287, 422),
-	NewVector2Int(321, 335),
-	NewVector2Int(152, 377),
-	NewVector2Int(165, 167),
-	NewVector2Int(167, 97),
-	NewVector2Int(43, 61),
-	NewVector2Int(306, 291),
-	NewVector2Int(79, 20),
-	NewVector2Int(465, 464),
-	NewVector2Int(432, 287),
-	NewVector2Int(195, 51),
-	NewVector2Int(221, 189),
-	NewVector2Int(239, 237),
-	NewVector2Int(85, 179),
-	NewVector2Int(272, 271),
-	NewVector2Int(445, 342),
-	NewVector2Int(344, 439),
-	NewVector2Int(42, 74),
-	NewVector2Int(162, 139),
-	NewVector2Int(347, 450),
-	NewVector2Int(133, 112),
-	NewVector2Int(387, 257),
-	NewVector2Int(460, 327),
-	NewVector2Int(57, 202),
-	NewVector2Int(271, 311),
-	NewVector2Int(123, 147),
-	NewVector2Int(437, 343),
-	NewVector2Int(275, 281),
-	NewVector2Int(340, 261),
-	NewVector2Int(161, 30),
-	NewVector2Int(338, 337),
-	NewVector2Int(357, 350),
-	NewVector2Int(217, 47),
-	NewVector2Int(309, 459),
-	NewVector2Int(255, 339),
-	NewVector2Int(287, 291),
-	NewVector2Int(139, 127),
-	NewVector2Int(268, 12),
-	NewVector2Int(38, 81),
-	NewVector2Int(230, 22),
-	NewVector2Int(101, 100),
-	NewVector2Int(81, 42),
-	NewVector2Int(220, 237),
-	NewVector2Int(404, 403),
-	NewVector2Int(351, 168),
-	NewVector2Int(405, 404),
-	NewVector2Int(61, 185),
-	NewVector2Int(396, 175),
-	NewVector2Int(93, 137),
-	NewVector2Int(79, 239),
-	NewVector2Int(313, 406),
-	NewVector2Int(424, 273),
-	NewVector2Int(266, 330),
-	NewVector2Int(196, 122),
-	NewVector2Int(435, 367),
-	NewVector2Int(108, 9),
-	NewVector2Int(242, 238),
-	NewVector2Int(375, 273),
-	NewVector2Int(293, 283),
-	NewVector2Int(205, 207),
-	NewVector2Int(123, 137),
-	NewVector2Int(130, 33),
-	NewVector2Int(223, 224),
-	NewVector2Int(230, 120),
-	NewVector2Int(408, 306),
-	NewVector2Int(92, 186),
-	NewVector2Int(345, 366),
-	NewVector2Int(18, 200),
-	NewVector2Int(65, 52),
-	NewVector2Int(76, 61),
-	NewVector2Int(9, 8),
-	NewVector2Int(342, 446),
-	NewVector2Int(14, 86),
-	NewVector2Int(202, 57),
-	NewVector2Int(335, 321),
-	NewVector2Int(20, 166),
-	NewVector2Int(379, 364),
-	NewVector2Int(265, 342),
-	NewVector2Int(32, 140),
-	NewVector2Int(385, 258),
-	NewVector2Int(390, 373),
-	NewVector2Int(231, 120),
-	NewVector2Int(77, 62),
-	NewVector2Int(257, 258),
-	NewVector2Int(15, 316),
-	NewVector2Int(42, 41),
-	NewVector2Int(289, 290),
-	NewVector2Int(340, 372),
-	NewVector2Int(187, 50),
-	NewVector2Int(352, 346),
-	NewVector2Int(70, 139),
-	NewVector2Int(416, 367),
-	NewVector2Int(41, 81),
-	NewVector2Int(285, 441),
-	NewVector2Int(277, 437),
-	NewVector2Int(178, 86),
-	NewVector2Int(224, 53),
-	NewVector2Int(284, 333),
-	NewVector2Int(116, 111),
-	NewVector2Int(108, 107),
-	NewVector2Int(415, 407),
-	NewVector2Int(1, 44),
-	NewVector2Int(327, 294),
-	NewVector2Int(439, 392),
-	NewVector2Int(57, 61),
-	NewVector2Int(138, 135),
-	NewVector2Int(109, 108),
-	NewVector2Int(229, 24),
-	NewVector2Int(106, 182),
-	NewVector2Int(277, 329),
-	NewVector2Int(278, 294),
-	NewVector2Int(274, 440),
-	NewVector2Int(436, 322),
-	NewVector2Int(422, 424),
-	NewVector2Int(286, 414),
-	NewVector2Int(360, 363),
-	NewVector2Int(359, 255),
-	NewVector2Int(85, 15),
-	NewVector2Int(397, 367),
-	NewVector2Int(224, 30),
-	NewVector2Int(27, 159),
-	NewVector2Int(139, 71),
-	NewVector2Int(406, 335),
-	NewVector2Int(344, 360),
-	NewVector2Int(348, 330),
-	NewVector2Int(418, 431),
-	NewVector2Int(269, 302),
-	NewVector2Int(189, 193),
-	NewVector2Int(36, 100),
-	NewVector2Int(230, 231),
-	NewVector2Int(169, 135),
-	NewVector2Int(441, 414),
-	NewVector2Int(447, 345),
-	NewVector2Int(407, 272),
-	NewVector2Int(44, 220),
-	NewVector2Int(100, 121),
-	NewVector2Int(200, 208),
-	NewVector2Int(264, 372),
-	NewVector2Int(270, 303),
-	NewVector2Int(271, 268),
-	NewVector2Int(276, 383),
-	NewVector2Int(434, 411),
-	NewVector2Int(198, 134),
-	NewVector2Int(144, 163),
-	NewVector2Int(411, 427),
-	NewVector2Int(349, 452),
-	NewVector2Int(275, 274),
-	NewVector2Int(242, 97),
-	NewVector2Int(307, 306),
-	NewVector2Int(133, 190),
-	NewVector2Int(234, 93),
-	NewVector2Int(5, 4),
-	NewVector2Int(445, 276),
-	NewVector2Int(329, 330),
-	NewVector2Int(374, 252),
-	NewVector2Int(27, 28),
-	NewVector2Int(0, 11),
-	NewVector2Int(437, 277),
-	NewVector2Int(382, 341),
-	NewVector2Int(140, 170),
-	NewVector2Int(280, 330),
-	NewVector2Int(166, 79),
-	NewVector2Int(111, 143),
-	NewVector2Int(226, 130),
-	NewVector2Int(186, 57),
-	NewVector2Int(391, 426),
-	NewVector2Int(105, 69),
-	NewVector2Int(123, 117),
-	NewVector2Int(256, 381),
-	NewVector2Int(301, 389),
-	NewVector2Int(428, 200),
-	NewVector2Int(167, 165),
-	NewVector2Int(132, 58),
-	NewVector2Int(19, 1),
-	NewVector2Int(125, 19),
-	NewVector2Int(89, 96),
-	NewVector2Int(418, 421),
-	NewVector2Int(340, 352),
-	NewVector2Int(48, 64),
-	NewVector2Int(228, 31),
-	NewVector2Int(459, 309),
-	NewVector2Int(72, 12),
-	NewVector2Int(169, 210),
-	NewVector2Int(215, 177),
-	NewVector2Int(172, 215),
-	NewVector2Int(215, 213),
-	NewVector2Int(455, 460),
-	NewVector2Int(417, 168),
-	NewVector2Int(461, 354),
-	NewVector2Int(413, 463),
-	NewVector2Int(290, 392),
-	NewVector2Int(43, 106),
-	NewVector2Int(423, 327),
-	NewVector2Int(300, 293),
-	NewVector2Int(34, 143),
-	NewVector2Int(207, 214),
-	NewVector2Int(119, 101),
-	NewVector2Int(299, 297),
-	NewVector2Int(377, 396),
-	NewVector2Int(171, 148),
-	NewVector2Int(40, 73),
-	NewVector2Int(41, 38),
-	NewVector2Int(273, 424),
-	NewVector2Int(146, 91),
-	NewVector2Int(141, 125),
-	NewVector2Int(299, 333),
-	NewVector2Int(12, 11),
-	NewVector2Int(218, 79),
-	NewVector2Int(50, 123),
-	NewVector2Int(255, 263),
-	NewVector2Int(180, 89),
-	NewVector2Int(233, 244),
-	NewVector2Int(45, 44),
-	NewVector2Int(217, 236),
-	NewVector2Int(351, 465),
-	NewVector2Int(96, 95),
-	NewVector2Int(187, 213),
-	NewVector2Int(113, 35),
-	NewVector2Int(352, 280),
-	NewVector2Int(426, 266),
-	NewVector2Int(292, 415),
-	NewVector2Int(65, 107),
-	NewVector2Int(164, 0),
-	NewVector2Int(2, 167),
-	NewVector2Int(22, 145),
-	NewVector2Int(444, 283),
-	NewVector2Int(429, 420),
-	NewVector2Int(34, 156),
-	NewVector2Int(100, 101),
-	NewVector2Int(291, 306),
-	NewVector2Int(138, 213),
-	NewVector2Int(391, 393),
-	NewVector2Int(43, 204),
-	NewVector2Int(294, 460),
-	NewVector2Int(414, 413),
-	NewVector2Int(319, 404),
-	NewVector2Int(420, 456),
-	NewVector2Int(352, 401),
-	NewVector2Int(413, 417),
-	NewVector2Int(292, 307),
-	NewVector2Int(175, 396),
-	NewVector2Int(223, 27),
-	NewVector2Int(209, 129),
-	NewVector2Int(422, 273),
-	NewVector2Int(214, 207),
-	NewVector2Int(206, 203),
-	NewVector2Int(131, 209),
-	NewVector2Int(232, 112),
-	NewVector2Int(425, 427),
-	NewVector2Int(392, 250),
-	NewVector2Int(457, 459),
-	NewVector2Int(444, 260),
-	NewVector2Int(281, 4),
-	NewVector2Int(433, 367),
-	NewVector2Int(199, 200),
-	NewVector2Int(197, 419),
-	NewVector2Int(11, 0),
-	NewVector2Int(344, 438),
-	NewVector2Int(122, 245),
-	NewVector2Int(300, 368),
-	NewVector2Int(86, 179),
-	NewVector2Int(32, 208),
-	NewVector2Int(232, 233),
-	NewVector2Int(446, 342),
-	NewVector2Int(203, 206),
-	NewVector2Int(449, 347),
-	NewVector2Int(202, 210),
-	NewVector2Int(19, 354),
-	NewVector2Int(19, 125),
-	NewVector2Int(220, 115),
-	NewVector2Int(412, 465),
-	NewVector2Int(206, 216),
-	NewVector2Int(154, 26),
-	NewVector2Int(452, 357),
-	NewVector2Int(70, 63),
-	NewVector2Int(170, 150),
-	NewVector2Int(135, 192),
-	NewVector2Int(69, 67),
-	NewVector2Int(421, 406),
-	NewVector2Int(367, 435),
-	NewVector2Int(328, 327),
-	NewVector2Int(333, 293),
-	NewVector2Int(139, 162),
-	NewVector2Int(305, 460),
-	NewVector2Int(97, 165),
-	NewVector2Int(393, 164),
-	NewVector2Int(303, 270),
-	NewVector2Int(268, 312),
-	NewVector2Int(341, 382),
-	NewVector2Int(421, 262),
-	NewVector2Int(440, 275),
-	NewVector2Int(25, 33),
-	NewVector2Int(258, 384),
-	NewVector2Int(332, 333),
-	NewVector2Int(364, 365),
-	NewVector2Int(269, 393),
-	NewVector2Int(222, 52),
-	NewVector2Int(437, 355),
-	NewVector2Int(96, 62),
-	NewVector2Int(6, 168),
-	NewVector2Int(440, 360),
-	NewVector2Int(58, 215),
-	NewVector2Int(397, 435),
-	NewVector2Int(465, 453),
-	NewVector2Int(358, 327),
-	NewVector2Int(417, 413),
-	NewVector2Int(135, 169),
-	NewVector2Int(125, 241),
-	NewVector2Int(326, 2),
-	NewVector2Int(291, 273),
-	NewVector2Int(49, 102),
-	NewVector2Int(75, 240),
-	NewVector2Int(61, 76),
-	NewVector2Int(372, 383),
-	NewVector2Int(205, 206),
-	NewVector2Int(184, 183),
-	NewVector2Int(144, 110),
-	NewVector2Int(23, 229),
-	NewVector2Int(412, 419),
-	NewVector2Int(9, 151),
-	NewVector2Int(62, 77),
-	NewVector2Int(327, 460),
-	NewVector2Int(325, 307),
-	NewVector2Int(310, 407),
-	NewVector2Int(329, 277),
-	NewVector2Int(195, 197),
-	NewVector2Int(267, 302),
-	NewVector2Int(10, 151),
-	NewVector2Int(170, 140),
-	NewVector2Int(28, 56),
-	NewVector2Int(288, 361),
-	NewVector2Int(363, 275),
-	NewVector2Int(15, 86),
-	NewVector2Int(463, 362),
-	NewVector2Int(209, 217),
-	NewVector2Int(106, 204),
-	NewVector2Int(213, 187),
-	NewVector2Int(386, 258),
-	NewVector2Int(215, 147),
-	NewVector2Int(127, 34),
-	NewVector2Int(387, 259),
-	NewVector2Int(71, 139),
-	NewVector2Int(240, 60),
-	NewVector2Int(432, 410),
-	NewVector2Int(241, 141),
-	NewVector2Int(214, 187),
-	NewVector2Int(225, 53),
-	NewVector2Int(111, 31),
-	NewVector2Int(430, 422),
-	NewVector2Int(117, 111),
-	NewVector2Int(182, 91),
-	NewVector2Int(229, 23),
-	NewVector2Int(134, 51),
-	NewVector2Int(174, 236),
-	NewVector2Int(319, 325),
-	NewVector2Int(419, 412),
-	NewVector2Int(9, 285),
-	NewVector2Int(98, 97),
-	NewVector2Int(282, 443),
-	NewVector2Int(73, 40),
-	NewVector2Int(302, 12),
-	NewVector2Int(318, 402),
-	NewVector2Int(404, 405),
-	NewVector2Int(275, 440),
-	NewVector2Int(34, 234),
-	NewVector2Int(65, 221),
-	NewVector2Int(86, 15),
-	NewVector2Int(405, 406),
-	NewVector2Int(358, 294),
-	NewVector2Int(225, 30),
-	NewVector2Int(116, 123),
-	NewVector2Int(309, 438),
-	NewVector2Int(135, 136),
-	NewVector2Int(196, 3),
-	NewVector2Int(440, 363),
-	NewVector2Int(272, 407),
-	NewVector2Int(467, 466),
-	NewVector2Int(273, 335),
-	NewVector2Int(438, 439),
-	NewVector2Int(226, 31),
-	NewVector2Int(110, 228),
-	NewVector2Int(391, 327),
-	NewVector2Int(449, 448),
-	NewVector2Int(197, 196),
-	NewVector2Int(129, 98),
-	NewVector2Int(61, 43),
-	NewVector2Int(106, 194),
-	NewVector2Int(52, 223),
-	NewVector2Int(237, 125),
-	NewVector2Int(33, 246),
-	NewVector2Int(341, 362),
-	NewVector2Int(53, 224),
-	NewVector2Int(408, 272),
-	NewVector2Int(45, 220),
-	NewVector2Int(280, 352),
-	NewVector2Int(351, 412),
-	NewVector2Int(271, 303),
-	NewVector2Int(231, 230),
-	NewVector2Int(267, 269),
-	NewVector2Int(79, 218),
-	NewVector2Int(37, 72),
-	NewVector2Int(390, 339),
-	NewVector2Int(313, 421),
-	NewVector2Int(157, 173),
-	NewVector2Int(437, 456),
-	NewVector2Int(458, 250),
-	NewVector2Int(47, 121),
-	NewVector2Int(60, 240),
-	NewVector2Int(412, 399),
-	NewVector2Int(39, 37),
-	NewVector2Int(76, 184),
-	NewVector2Int(123, 116),
-	NewVector2Int(362, 398),
-	NewVector2Int(309, 392),
-	NewVector2Int(302, 267),
-	NewVector2Int(293, 298),
-	NewVector2Int(92, 165),
-	NewVector2Int(4, 281),
-	NewVector2Int(186, 92),
-	NewVector2Int(58, 172),
-	NewVector2Int(3, 236),
-	NewVector2Int(105, 104),
-	NewVector2Int(178, 88),
-	NewVector2Int(418, 335),
-	NewVector2Int(395, 431),
-	NewVector2Int(108, 109),
-	NewVector2Int(428, 199),
-	NewVector2Int(194, 32),
-	NewVector2Int(99, 60),
-	NewVector2Int(250, 458),
-	NewVector2Int(97, 99),
-	NewVector2Int(83, 201),
-	NewVector2Int(98, 64),
-	NewVector2Int(343, 277),
-	NewVector2Int(35, 124),
-	NewVector2Int(114, 188),
-	NewVector2Int(5, 281),
-	NewVector2Int(440, 438),
-	NewVector2Int(372, 340),
-	NewVector2Int(243, 189),
-	NewVector2Int(90, 96),
-	NewVector2Int(419, 6),
-	NewVector2Int(323, 447),
-	NewVector2Int(9, 108),
-	NewVector2Int(289, 305),
-	NewVector2Int(216, 92),
-	NewVector2Int(433, 435),
-	NewVector2Int(212, 207),
-	NewVector2Int(411, 416),
-	NewVector2Int(130, 25),
-	NewVector2Int(129, 209),
-	NewVector2Int(441, 295),
-	NewVector2Int(392, 439),
-	NewVector2Int(91, 182),
-	NewVector2Int(331, 279),
-	NewVector2Int(270, 269),
-	NewVector2Int(329, 355),
-	NewVector2Int(295, 442),
-	NewVector2Int(241, 242),
-	NewVector2Int(301, 293),
-	NewVector2Int(18, 313),
-	NewVector2Int(93, 227),
-	NewVector2Int(399, 419),
-	NewVector2Int(419, 248),
-	NewVector2Int(303, 302),
-	NewVector2Int(274, 275),
-	NewVector2Int(41, 73),
-	NewVector2Int(252, 256),
-	NewVector2Int(37, 39),
-	NewVector2Int(304, 303),
-	NewVector2Int(156, 139),
-	NewVector2Int(296, 299),
-	NewVector2Int(351, 6),
-	NewVector2Int(242, 99),
-	NewVector2Int(188, 128),
-	NewVector2Int(467, 446),
-	NewVector2Int(371, 329),
-	NewVector2Int(337, 336),
-	NewVector2Int(402, 316),
-	NewVector2Int(235, 59),
-	NewVector2Int(67, 103),
-	NewVector2Int(125, 44),
-	NewVector2Int(56, 173),
-	NewVector2Int(145, 22),
-	NewVector2Int(464, 413),
-	NewVector2Int(190, 173),
-	NewVector2Int(267, 393),
-	NewVector2Int(319, 403),
-	NewVector2Int(72, 37),
-	NewVector2Int(232, 26),
-	NewVector2Int(292, 306),
-	NewVector2Int(403, 402),
-	NewVector2Int(320, 404),
-	NewVector2Int(360, 440),
-	NewVector2Int(441, 285),
-	NewVector2Int(368, 301),
-	NewVector2Int(425, 426),
-	NewVector2Int(2, 94),
-	NewVector2Int(341, 463),
-	NewVector2Int(208, 199),
-	NewVector2Int(215, 58),
-	NewVector2Int(407, 408),
-	NewVector2Int(101, 36),
-	NewVector2Int(455, 305),
-	NewVector2Int(400, 369),
-	NewVector2Int(126, 129),
-	NewVector2Int(233, 112),
-	NewVector2Int(412, 343),
-	NewVector2Int(458, 459),
-	NewVector2Int(63, 46),
-	NewVector2Int(135, 214),
-	NewVector2Int(421, 428),
-	NewVector2Int(86, 178),
-	NewVector2Int(449, 346),
-	NewVector2Int(301, 368),
-	NewVector2Int(137, 116),
-	NewVector2Int(450, 347),
-	NewVector2Int(237, 239),
-	NewVector2Int(83, 181),
-	NewVector2Int(170, 149),
-	NewVector2Int(243, 133),
-	NewVector2Int(384, 258),
-	NewVector2Int(370, 94),
-	NewVector2Int(208, 32),
-	NewVector2Int(40, 39),
-	NewVector2Int(155, 26),
-	NewVector2Int(207, 216),
-	NewVector2Int(213, 147),
-	NewVector2Int(453, 357),
-	NewVector2Int(11, 12),
-	NewVector2Int(71, 63),
-	NewVector2Int(290, 250),
-	NewVector2Int(128, 188),
-	NewVector2Int(409, 287),
-	NewVector2Int(268, 311),
-	NewVector2Int(334, 293),
-	NewVector2Int(440, 274),
-	NewVector2Int(44, 45),
-	NewVector2Int(98, 165),
-	NewVector2Int(304, 270),
-	NewVector2Int(22, 26),
-	NewVector2Int(455, 439),
-	NewVector2Int(74, 73),
-	NewVector2Int(66, 69),
-	NewVector2Int(31, 111),
-	NewVector2Int(131, 198),
-	NewVector2Int(184, 61),
-	NewVector2Int(28, 222),
-	NewVector2Int(410, 287),
-	NewVector2Int(436, 425),
-	NewVector2Int(409, 408),
-	NewVector2Int(223, 52),
-	NewVector2Int(428, 421),
-	NewVector2Int(376, 411),
-	NewVector2Int(141, 19),
-	NewVector2Int(144, 24),
-	NewVector2Int(123, 50),
-	NewVector2Int(142, 100),
-	NewVector2Int(442, 441),
-	NewVector2Int(421, 418),
-	NewVector2Int(448, 255),
-	NewVector2Int(138, 215),
-	NewVector2Int(443, 442),
-	NewVector2Int(62, 76),
-	NewVector2Int(355, 329),
-	NewVector2Int(375, 307),
-	NewVector2Int(227, 143),
-	NewVector2Int(20, 79),
-	NewVector2Int(39, 92),
-	NewVector2Int(24, 229),
-	NewVector2Int(250, 392),
-	NewVector2Int(219, 59),
-	NewVector2Int(36, 203),
-	NewVector2Int(223, 29),
-	NewVector2Int(209, 131),
-	NewVector2Int(328, 460),
-	NewVector2Int(206, 205),
-	NewVector2Int(347, 330),
-	NewVector2Int(306, 292),
-	NewVector2Int(2, 326),
-	NewVector2Int(184, 74),
-	NewVector2Int(457, 461),
-	NewVector2Int(228, 229),
-	NewVector2Int(368, 389),
-	NewVector2Int(239, 238),
-	NewVector2Int(85, 180),
-	NewVector2Int(137, 227),
-	NewVector2Int(171, 140),
-	NewVector2Int(210, 211),
-	NewVector2Int(138, 192),
-	NewVector2Int(77, 96),
-	NewVector2Int(458, 462),
-	NewVector2Int(344, 440),
-	NewVector2Int(11, 267),
-	NewVector2Int(242, 20),
-	NewVector2Int(126, 217),
-	NewVector2Int(243, 244),
-	NewVector2Int(324, 318),
-	NewVector2Int(211, 32),
-	NewVector2Int(55, 193),
-	NewVector2Int(395, 378),
-	NewVector2Int(202, 212),
-	NewVector2Int(360, 420),
-	NewVector2Int(325, 319),
-	NewVector2Int(457, 438),
-	NewVector2Int(40, 186),
-	NewVector2Int(276, 283),
-	NewVector2Int(294, 331),
-	NewVector2Int(242, 141),
-	NewVector2Int(187, 205),
-	NewVector2Int(396, 199),
-	NewVector2Int(268, 13),
-	NewVector2Int(292, 407),
-	NewVector2Int(298, 301),
-	NewVector2Int(404, 319),
-	NewVector2Int(9, 55),
-	NewVector2Int(370, 326),
-	NewVector2Int(68, 104),
-	NewVector2Int(281, 248),
-	NewVector2Int(230, 23),
-	NewVector2Int(74, 184),
-	NewVector2Int(320, 325),
-	NewVector2Int(141, 94),
-	NewVector2Int(60, 20),
-	NewVector2Int(97, 167),
-	NewVector2Int(104, 63),
-	NewVector2Int(283, 443),
-	NewVector2Int(69, 105),
-	NewVector2Int(300, 383),
-	NewVector2Int(48, 131),
-	NewVector2Int(401, 435),
-	NewVector2Int(74, 40),
-	NewVector2Int(258, 386),
-	NewVector2Int(86, 14),
-	NewVector2Int(364, 367),
-	NewVector2Int(432, 422),
-	NewVector2Int(424, 418),
-	NewVector2Int(177, 132),
-	NewVector2Int(282, 334),
-	NewVector2Int(329, 266),
-	NewVector2Int(394, 395),
-	NewVector2Int(372, 264),
-	NewVector2Int(430, 434),
-	NewVector2Int(117, 123),
-	NewVector2Int(88, 96),
-	NewVector2Int(180, 179),
-	NewVector2Int(406, 405),
-	NewVector2Int(45, 134),
-	NewVector2Int(53, 223),
-	NewVector2Int(91, 106),
-	NewVector2Int(271, 302),
-	NewVector2Int(283, 276),
-	NewVector2Int(76, 62),
-	NewVector2Int(236, 51),
-	NewVector2Int(96, 77),
-	NewVector2Int(8, 193),
-	NewVector2Int(247, 246),
-	NewVector2Int(368, 356),
-	NewVector2Int(100, 47),
-	NewVector2Int(279, 278),
-	NewVector2Int(452, 349),
-	NewVector2Int(379, 365),
-	NewVector2Int(38, 72),
-	NewVector2Int(399, 343),
-	NewVector2Int(257, 259),
-	NewVector2Int(377, 175),
-	NewVector2Int(249, 255),
-	NewVector2Int(354, 457),
-	NewVector2Int(239, 241),
-	NewVector2Int(312, 311),
-	NewVector2Int(150, 169),
-	NewVector2Int(157, 28),
-	NewVector2Int(182, 201),
-	NewVector2Int(19, 94),
-	NewVector2Int(39, 72),
-	NewVector2Int(4, 51),
-	NewVector2Int(97, 242),
-	NewVector2Int(290, 328),
-	NewVector2Int(336, 295),
-	NewVector2Int(194, 211),
-	NewVector2Int(293, 333),
-	NewVector2Int(340, 265),
-	NewVector2Int(25, 110),
-	NewVector2Int(291, 409),
-	NewVector2Int(445, 467),
-	NewVector2Int(366, 323),
-	NewVector2Int(46, 53),
-	NewVector2Int(278, 439),
-	NewVector2Int(65, 66),
-	NewVector2Int(430, 424),
-	NewVector2Int(68, 71),
-	NewVector2Int(5, 51),
-	NewVector2Int(30, 224),
-	NewVector2Int(186, 212),
-	NewVector2Int(138, 172),
-	NewVector2Int(98, 99),
-	NewVector2Int(85, 16),
-	NewVector2Int(431, 424),
-	NewVector2Int(244, 189),
-	NewVector2Int(290, 305),
-	NewVector2Int(454, 264),
-	NewVector2Int(373, 339),
-	NewVector2Int(240, 75),
-	NewVector2Int(445, 444),
-	NewVector2Int(112, 155),
-	NewVector2Int(392, 438),
-	NewVector2Int(135, 138),
-	NewVector2Int(216, 212),
-	NewVector2Int(122, 168),
-	NewVector2Int(26, 231),
-	NewVector2Int(129, 64),
-	NewVector2Int(446, 265),
-	NewVector2Int(203, 129),
-	NewVector2Int(143, 227),
-	NewVector2Int(457, 274),
-	NewVector2Int(220, 218),
-	NewVector2Int(18, 83),
-	NewVector2Int(357, 452),
-	NewVector2Int(277, 343),
-	NewVector2Int(270, 304),
-	NewVector2Int(261, 255),
-	NewVector2Int(41, 72),
-	NewVector2Int(393, 2),
-	NewVector2Int(231, 232),
-	NewVector2Int(16, 15),
-	NewVector2Int(261, 340),
-	NewVector2Int(448, 346),
-	NewVector2Int(381, 256),
-	NewVector2Int(467, 445),
-	NewVector2Int(393, 267),
-	NewVector2Int(17, 16),
-	NewVector2Int(262, 421),
-	NewVector2Int(296, 334),
-	NewVector2Int(49, 48),
-	NewVector2Int(297, 299),
-	NewVector2Int(182, 83),
-	NewVector2Int(227, 234),
-	NewVector2Int(201, 182),
-	NewVector2Int(374, 253),
-	NewVector2Int(27, 29),
-	NewVector2Int(403, 316),
-	NewVector2Int(148, 175),
-	NewVector2Int(213, 192),
-	NewVector2Int(219, 235),
-	NewVector2Int(63, 104),
-	NewVector2Int(104, 105),
-	NewVector2Int(256, 382),
-	NewVector2Int(128, 233),
-	NewVector2Int(302, 269),
-	NewVector2Int(293, 300),
-	NewVector2Int(59, 219),
-	NewVector2Int(465, 412),
-	NewVector2Int(423, 266),
-	NewVector2Int(260, 388),
-	NewVector2Int(307, 320),
-	NewVector2Int(113, 226),
-	NewVector2Int(51, 134),
-	NewVector2Int(446, 255),
-	NewVector2Int(47, 100),
-	NewVector2Int(147, 187),
-	NewVector2Int(460, 455),
-	NewVector2Int(151, 337),
-	NewVector2Int(165, 92),
-	NewVector2Int(263, 255),
-	NewVector2Int(182, 181),
-	NewVector2Int(442, 257),
-	NewVector2Int(389, 251),
-	NewVector2Int(60, 75),
-	NewVector2Int(57, 186),
-	NewVector2Int(258, 441),
-	NewVector2Int(413, 464),
-	NewVector2Int(339, 249),
-	NewVector2Int(131, 220),
-	NewVector2Int(376, 433),
-	NewVector2Int(235, 48),
-	NewVector2Int(112, 243),
-	NewVector2Int(312, 268),
-	NewVector2Int(84, 181),
-	NewVector2Int(238, 239),
-	NewVector2Int(102, 49),
-	NewVector2Int(456, 281),
-	NewVector2Int(40, 74),
-	NewVector2Int(352, 340),
-	NewVector2Int(299, 334),
-	NewVector2Int(31, 25),
-	NewVector2Int(212, 186),
-	NewVector2Int(271, 272),
-	NewVector2Int(425, 330),
-	NewVector2Int(51, 3),
-	NewVector2Int(303, 304),
-	NewVector2Int(314, 313),
-	NewVector2Int(254, 448),
-	NewVector2Int(360, 429),
-	NewVector2Int(316, 14),
-	NewVector2Int(180, 90),
-	NewVector2Int(233, 245),
-	NewVector2Int(295, 336),
-	NewVector2Int(336, 337),
-	NewVector2Int(101, 205),
-	NewVector2Int(28, 221),
-	NewVector2Int(315, 314),
-	NewVector2Int(341, 452),
-	NewVector2Int(67, 69),
-	NewVector2Int(318, 325),
-	NewVector2Int(144, 23),
-	NewVector2Int(337, 338),
-	NewVector2Int(348, 347),
-	NewVector2Int(275, 363),
-	NewVector2Int(147, 177),
-	NewVector2Int(181, 90),
-	NewVector2Int(366, 401),
-	NewVector2Int(218, 237),
-	NewVector2Int(63, 71),
-	NewVector2Int(338, 10),
-	NewVector2Int(402, 403),
-	NewVector2Int(375, 306),
-	NewVector2Int(72, 39),
-	NewVector2Int(24, 228),
-	NewVector2Int(292, 308),
-	NewVector2Int(403, 404),
-	NewVector2Int(61, 146),
-	NewVector2Int(369, 262),
-	NewVector2Int(395, 400),
-	NewVector2Int(165, 203),
-	NewVector2Int(430, 394),
-	NewVector2Int(75, 166),
-	NewVector2Int(110, 7),
-	NewVector2Int(281, 5),
-	NewVector2Int(207, 205),
-	NewVector2Int(112, 233),
-	NewVector2Int(198, 236),
-	NewVector2Int(227, 34),
-	NewVector2Int(458, 461),
-	NewVector2Int(129, 142),
-	NewVector2Int(334, 282),
-	NewVector2Int(201, 18),
-	NewVector2Int(33, 25),
-	NewVector2Int(166, 60),
-	NewVector2Int(251, 284),
-	NewVector2Int(226, 111),
-	NewVector2Int(169, 214),
-	NewVector2Int(325, 318),
-	NewVector2Int(450, 349),
-	NewVector2Int(40, 185),
-}
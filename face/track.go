@@ -0,0 +1,92 @@
+package face
+
+import (
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/tracking"
+	"github.com/recolude/rap/format/collection/float"
+)
+
+// faceTrack is every capture belonging to a single face as it moves through
+// the clip.
+type faceTrack struct {
+	*tracking.Subject
+
+	// blendshapes are the face's blendshape coefficients every frame they
+	// could be measured, indexed the same as BlendshapeNames. Nil unless
+	// blendshapes are being recorded.
+	blendshapes [][]float.Capture
+
+	// eyeAspectRatios are how open each of the face's eyes are every frame
+	// they could be measured, indexed the same as faceSides. Nil unless blinks
+	// are being detected.
+	eyeAspectRatios [][]float.Capture
+}
+
+func newFaceTrack(smoothing filter.Factory, landmarks int, blendshapes, blinks bool) *faceTrack {
+	track := &faceTrack{
+		Subject: tracking.NewSubject(smoothing, landmarks),
+	}
+	if blendshapes {
+		track.blendshapes = make([][]float.Capture, len(BlendshapeNames))
+	}
+	if blinks {
+		track.eyeAspectRatios = make([][]float.Capture, len(faceSides))
+	}
+	return track
+}
+
+func (ft *faceTrack) add(curTime float64, face []landmark.LandMark, maxGap float64) {
+	marks := ft.Subject.Add(curTime, face, maxGap)
+	if ft.blendshapes == nil && ft.eyeAspectRatios == nil {
+		return
+	}
+
+	fp := newFacePoints(marks)
+	if ft.blendshapes != nil {
+		if weights, ok := blendshapes(fp); ok {
+			for i, name := range BlendshapeNames {
+				ft.blendshapes[i] = append(ft.blendshapes[i], float.NewCapture(curTime, weights[name]))
+			}
+		}
+	}
+	if ft.eyeAspectRatios != nil {
+		for i, side := range faceSides {
+			if ratio, ok := eyeAspectRatio(fp, side); ok {
+				ft.eyeAspectRatios[i] = append(ft.eyeAspectRatios[i], float.NewCapture(curTime, ratio))
+			}
+		}
+	}
+}
+
+// complete is whether or not every vertex of a face mesh made up of the
+// provided number of vertices has been captured at least once.
+func (ft *faceTrack) complete(vertices int) bool {
+	if vertices == 0 || len(ft.Captures) < vertices {
+		return false
+	}
+	for i := 0; i < vertices; i++ {
+		if len(ft.Captures[i]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// matchFaces pairs every face detected within a frame with the track it most
+// likely belongs to. Faces further than maxDistance from every available track
+// are left unmatched, represented by a nil track.
+func matchFaces(tracks []*faceTrack, faces [][]landmark.LandMark, maxDistance float64) []*faceTrack {
+	subjects := make([]*tracking.Subject, len(tracks))
+	for i, track := range tracks {
+		subjects[i] = track.Subject
+	}
+
+	matches := make([]*faceTrack, len(faces))
+	for faceIndex, trackIndex := range tracking.MatchSubjects(subjects, faces, maxDistance, nil) {
+		if trackIndex != -1 {
+			matches[faceIndex] = tracks[trackIndex]
+		}
+	}
+	return matches
+}
@@ -0,0 +1,29 @@
+package filter
+
+// ExponentialMovingAverage blends each new value with the running average,
+// weighting the new value by Alpha.
+type ExponentialMovingAverage struct {
+	// Alpha is within (0, 1]. Lower values smooth more but lag further behind.
+	Alpha float64
+
+	initialized bool
+	average     float64
+}
+
+// NewExponentialMovingAverage creates a factory for moving average filters
+// with the provided alpha.
+func NewExponentialMovingAverage(alpha float64) Factory {
+	return func() Filter {
+		return &ExponentialMovingAverage{Alpha: alpha}
+	}
+}
+
+func (ema *ExponentialMovingAverage) Filter(t, x float64) float64 {
+	if !ema.initialized {
+		ema.initialized = true
+		ema.average = x
+		return x
+	}
+	ema.average = ema.Alpha*x + (1-ema.Alpha)*ema.average
+	return ema.average
+}
@@ -0,0 +1,60 @@
+// Package filter smooths the jitter out of landmark trajectories before they
+// are turned into captures.
+package filter
+
+import (
+	"github.com/recolude/pose-recording/landmark"
+)
+
+// Filter smooths a single stream of values as they arrive over time.
+type Filter interface {
+	// Filter takes the next raw value of the stream and the time it occurred,
+	// and returns the smoothed value.
+	Filter(t, x float64) float64
+}
+
+// Factory builds a new filter. A separate filter is built for every axis of
+// every landmark being smoothed.
+type Factory func() Filter
+
+type axes [3]Filter
+
+// Stage smooths every landmark of the frames passed through it, keeping a
+// set of filters per landmark ID.
+type Stage struct {
+	factory   Factory
+	landmarks map[int]*axes
+}
+
+// NewStage creates a stage that builds its filters from the provided factory.
+func NewStage(factory Factory) *Stage {
+	return &Stage{
+		factory:   factory,
+		landmarks: make(map[int]*axes),
+	}
+}
+
+// Apply smooths the landmarks found at time t, returning a new slice of
+// smoothed landmarks.
+func (s *Stage) Apply(t float64, marks []landmark.LandMark) []landmark.LandMark {
+	smoothed := make([]landmark.LandMark, len(marks))
+	for i, mark := range marks {
+		filters, ok := s.landmarks[mark.ID]
+		if !ok {
+			filters = &axes{s.factory(), s.factory(), s.factory()}
+			s.landmarks[mark.ID] = filters
+		}
+
+		smoothed[i] = mark
+		smoothed[i].X = filters[0].Filter(t, mark.X)
+		smoothed[i].Y = filters[1].Filter(t, mark.Y)
+		smoothed[i].Z = filters[2].Filter(t, mark.Z)
+	}
+	return smoothed
+}
+
+// Reset forgets every landmark's filters, so the next frame passed through
+// the stage is taken as is rather than blended with the frames before it.
+func (s *Stage) Reset() {
+	s.landmarks = make(map[int]*axes)
+}
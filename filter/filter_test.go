@@ -0,0 +1,256 @@
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+)
+
+const tolerance = 1e-9
+
+func run(factory Factory, times, values []float64) []float64 {
+	f := factory()
+	out := make([]float64, len(values))
+	for i, x := range values {
+		out[i] = f.Filter(times[i], x)
+	}
+	return out
+}
+
+func steps(count int, step float64) []float64 {
+	times := make([]float64, count)
+	for i := range times {
+		times[i] = float64(i) * step
+	}
+	return times
+}
+
+func TestFiltersHoldConstantValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		factory Factory
+	}{
+		{"ema", NewExponentialMovingAverage(0.5)},
+		{"one-euro", NewOneEuro(1, 10, 1)},
+		{"savitzky-golay", NewSavitzkyGolay(9, 2)},
+		{"kalman", NewKalman(1, 0.0001)},
+	}
+
+	times := steps(30, 1./30)
+	values := make([]float64, len(times))
+	for i := range values {
+		values[i] = 0.25
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, got := range run(tc.factory, times, values) {
+				if math.Abs(got-0.25) > tolerance {
+					t.Fatalf("value %d: got %g, want 0.25", i, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFiltersPassFirstValueThrough(t *testing.T) {
+	tests := []struct {
+		name    string
+		factory Factory
+	}{
+		{"ema", NewExponentialMovingAverage(0.1)},
+		{"one-euro", NewOneEuro(0.1, 0, 1)},
+		{"savitzky-golay", NewSavitzkyGolay(5, 3)},
+		{"kalman", NewKalman(1, 10)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.factory().Filter(2, 0.7); got != 0.7 {
+				t.Fatalf("got %g, want 0.7", got)
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		alpha  float64
+		values []float64
+		want   []float64
+	}{
+		{"alpha one follows", 1, []float64{1, 5, -2}, []float64{1, 5, -2}},
+		{"alpha half", 0.5, []float64{0, 1, 1}, []float64{0, 0.5, 0.75}},
+		{"alpha quarter", 0.25, []float64{4, 0, 0}, []float64{4, 3, 2.25}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := run(NewExponentialMovingAverage(tc.alpha), steps(len(tc.values), 1), tc.values)
+			for i := range tc.want {
+				if math.Abs(got[i]-tc.want[i]) > tolerance {
+					t.Fatalf("value %d: got %g, want %g", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSavitzkyGolayFollowsPolynomials(t *testing.T) {
+	tests := []struct {
+		name   string
+		window int
+		order  int
+		curve  func(t float64) float64
+	}{
+		{"line with order 1", 5, 1, func(t float64) float64 { return 2*t - 1 }},
+		{"parabola with order 2", 9, 2, func(t float64) float64 { return 3*t*t - t + 0.5 }},
+		{"cubic with order 3", 7, 3, func(t float64) float64 { return t*t*t - 2*t }},
+		{"uneven steps", 9, 2, func(t float64) float64 { return t * t }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			times := steps(20, 1./30)
+			if tc.name == "uneven steps" {
+				for i := range times {
+					times[i] += 0.01 * float64(i%3)
+				}
+			}
+			values := make([]float64, len(times))
+			for i, at := range times {
+				values[i] = tc.curve(at)
+			}
+
+			for i, got := range run(NewSavitzkyGolay(tc.window, tc.order), times, values) {
+				if math.Abs(got-values[i]) > 1e-6 {
+					t.Fatalf("value %d: got %g, want %g", i, got, values[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSavitzkyGolaySmoothsNoise(t *testing.T) {
+	times := steps(60, 1./30)
+	values := make([]float64, len(times))
+	for i := range values {
+		values[i] = 0.5
+		if i%2 == 1 {
+			values[i] += 0.02
+		}
+	}
+
+	got := run(NewSavitzkyGolay(9, 1), times, values)
+	for i := 20; i < len(got); i++ {
+		if math.Abs(got[i]-0.51) > 0.006 {
+			t.Fatalf("value %d: got %g, want within 0.006 of 0.51", i, got[i])
+		}
+	}
+}
+
+func TestSmoothingFiltersLagBehindSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		factory Factory
+	}{
+		{"ema", NewExponentialMovingAverage(0.5)},
+		{"one-euro", NewOneEuro(1, 0, 1)},
+		{"kalman", NewKalman(1, 0.0001)},
+	}
+
+	times := steps(4, 1./30)
+	values := []float64{0, 1, 1, 1}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := run(tc.factory, times, values)
+			if got[1] <= 0 || got[1] >= 1 {
+				t.Fatalf("got %g right after the step, want within (0, 1)", got[1])
+			}
+			if got[3] < got[1] {
+				t.Fatalf("got %g, then %g, want the value to keep approaching 1", got[1], got[3])
+			}
+		})
+	}
+}
+
+func TestOneEuroBetaReducesLag(t *testing.T) {
+	times := steps(10, 1./30)
+	values := make([]float64, len(times))
+	for i := range values {
+		values[i] = float64(i) * 0.1
+	}
+
+	still := run(NewOneEuro(1, 0, 1), times, values)
+	fast := run(NewOneEuro(1, 100, 1), times, values)
+	last := len(values) - 1
+	if values[last]-fast[last] >= values[last]-still[last] {
+		t.Fatalf("got lag %g with beta, %g without, want less lag with beta", values[last]-fast[last], values[last]-still[last])
+	}
+}
+
+func TestStageReset(t *testing.T) {
+	stage := NewStage(NewExponentialMovingAverage(0.5))
+	mark := func(x float64) []landmark.LandMark {
+		return []landmark.LandMark{{ID: 3, X: x, Y: x, Z: x}}
+	}
+
+	stage.Apply(0, mark(0))
+	if got := stage.Apply(1, mark(1))[0].X; got != 0.5 {
+		t.Fatalf("got %g before reset, want 0.5", got)
+	}
+
+	stage.Reset()
+	if got := stage.Apply(2, mark(1))[0].X; got != 1 {
+		t.Fatalf("got %g after reset, want 1", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"ema", false},
+		{"ema:alpha=0.2", false},
+		{" ema : alpha = 1 ", false},
+		{"ema:alpha=0", true},
+		{"ema:alpha=1.5", true},
+		{"ema:beta=1", true},
+		{"ema:alpha", true},
+		{"ema:alpha=abc", true},
+		{"one-euro:min-cutoff=0.5,beta=20", false},
+		{"one-euro:min-cutoff=0", true},
+		{"one-euro:beta=-1", true},
+		{"savitzky-golay", false},
+		{"savitzky-golay:window=255,order=3", false},
+		{"savitzky-golay:window=256", true},
+		{"savitzky-golay:window=1e12", true},
+		{"savitzky-golay:window=2,order=2", true},
+		{"savitzky-golay:window=9.5", true},
+		{"savitzky-golay:order=-1", true},
+		{"kalman:process-noise=2,measurement-noise=0.01", false},
+		{"kalman:measurement-noise=0", true},
+		{"median", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			factory, err := Parse(tc.spec)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("got no error, want one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			if factory() == nil {
+				t.Fatalf("got a factory building nil filters")
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package filter
+
+// Kalman tracks a value with a constant velocity model, treating every new
+// value as a noisy measurement of the value's true position.
+type Kalman struct {
+	// ProcessNoise is how much the value is expected to accelerate, in units
+	// per second squared. Higher values follow the measurements more closely.
+	ProcessNoise float64
+
+	// MeasurementNoise is the variance of the noise within each measurement.
+	// Higher values smooth more.
+	MeasurementNoise float64
+
+	initialized bool
+	lastTime    float64
+
+	// State estimate of position and velocity
+	position float64
+	velocity float64
+
+	// Covariance of the state estimate
+	p00, p01, p10, p11 float64
+}
+
+// NewKalman creates a factory for Kalman filters with the provided noise
+// parameters.
+func NewKalman(processNoise, measurementNoise float64) Factory {
+	return func() Filter {
+		return &Kalman{
+			ProcessNoise:     processNoise,
+			MeasurementNoise: measurementNoise,
+		}
+	}
+}
+
+func (k *Kalman) Filter(t, x float64) float64 {
+	if !k.initialized {
+		k.initialized = true
+		k.lastTime = t
+		k.position = x
+		k.velocity = 0
+		k.p00, k.p01, k.p10, k.p11 = k.MeasurementNoise, 0, 0, k.MeasurementNoise
+		return x
+	}
+
+	dt := t - k.lastTime
+	if dt <= 0 {
+		dt = minTimeStep
+	}
+	k.lastTime = t
+
+	// Predict
+	k.position += k.velocity * dt
+
+	q := k.ProcessNoise
+	dt2 := dt * dt
+	p00 := k.p00 + dt*(k.p10+k.p01) + dt2*k.p11 + q*dt2*dt2/4
+	p01 := k.p01 + dt*k.p11 + q*dt2*dt/2
+	p10 := k.p10 + dt*k.p11 + q*dt2*dt/2
+	p11 := k.p11 + q*dt2
+
+	// Update
+	innovation := x - k.position
+	s := p00 + k.MeasurementNoise
+	k0 := p00 / s
+	k1 := p10 / s
+
+	k.position += k0 * innovation
+	k.velocity += k1 * innovation
+
+	k.p00 = (1 - k0) * p00
+	k.p01 = (1 - k0) * p01
+	k.p10 = p10 - k1*p00
+	k.p11 = p11 - k1*p01
+
+	return k.position
+}
@@ -0,0 +1,81 @@
+package filter
+
+import "math"
+
+// minTimeStep is used in place of the elapsed time whenever two values arrive
+// out of order or at the same time.
+const minTimeStep = 1e-6
+
+// lowPass is a first order low pass filter.
+type lowPass struct {
+	initialized bool
+	last        float64
+}
+
+func (lp *lowPass) filter(x, alpha float64) float64 {
+	if !lp.initialized {
+		lp.initialized = true
+		lp.last = x
+		return x
+	}
+	lp.last = alpha*x + (1-alpha)*lp.last
+	return lp.last
+}
+
+func smoothingFactor(dt, cutoff float64) float64 {
+	r := 2 * math.Pi * cutoff * dt
+	return r / (r + 1)
+}
+
+// OneEuro is the speed adaptive low pass filter described by Casiez et al. in
+// "1€ Filter: A Simple Speed-based Low-pass Filter for Noisy Input in
+// Interactive Systems". Slow movements are smoothed heavily to remove jitter,
+// while fast movements are smoothed less to reduce lag.
+type OneEuro struct {
+	// MinCutoff is the cutoff frequency (Hz) used when the value is still.
+	// Lower values remove more jitter.
+	MinCutoff float64
+
+	// Beta is how much the cutoff frequency grows with speed. Higher values
+	// reduce lag.
+	Beta float64
+
+	// DerivativeCutoff is the cutoff frequency (Hz) used to smooth the speed.
+	DerivativeCutoff float64
+
+	initialized bool
+	lastTime    float64
+	x           lowPass
+	dx          lowPass
+}
+
+// NewOneEuro creates a factory for One Euro filters with the provided
+// parameters.
+func NewOneEuro(minCutoff, beta, derivativeCutoff float64) Factory {
+	return func() Filter {
+		return &OneEuro{
+			MinCutoff:        minCutoff,
+			Beta:             beta,
+			DerivativeCutoff: derivativeCutoff,
+		}
+	}
+}
+
+func (oe *OneEuro) Filter(t, x float64) float64 {
+	if !oe.initialized {
+		oe.initialized = true
+		oe.lastTime = t
+		oe.dx.filter(0, 1)
+		return oe.x.filter(x, 1)
+	}
+
+	dt := t - oe.lastTime
+	if dt <= 0 {
+		dt = minTimeStep
+	}
+	oe.lastTime = t
+
+	dx := oe.dx.filter((x-oe.x.last)/dt, smoothingFactor(dt, oe.DerivativeCutoff))
+	cutoff := oe.MinCutoff + oe.Beta*math.Abs(dx)
+	return oe.x.filter(x, smoothingFactor(dt, cutoff))
+}
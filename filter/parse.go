@@ -0,0 +1,130 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSavitzkyGolayWindow bounds the savitzky-golay window, as every filtered
+// value keeps a window's worth of history and refits it every frame.
+const maxSavitzkyGolayWindow = 255
+
+type params map[string]float64
+
+func (p params) get(key string, fallback float64) float64 {
+	if val, ok := p[key]; ok {
+		delete(p, key)
+		return val
+	}
+	return fallback
+}
+
+// unused reports any parameters that were provided but never read.
+func (p params) unused(name string) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return fmt.Errorf("unknown parameters for %s filter: %s", name, strings.Join(keys, ", "))
+}
+
+func parseParams(raw string) (params, error) {
+	p := make(params)
+	if strings.TrimSpace(raw) == "" {
+		return p, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		keyVal := strings.SplitN(pair, "=", 2)
+		if len(keyVal) != 2 {
+			return nil, fmt.Errorf("filter parameter %q is not in the form key=value", pair)
+		}
+
+		val, err := strconv.ParseFloat(strings.TrimSpace(keyVal[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("filter parameter %q: %w", pair, err)
+		}
+		p[strings.TrimSpace(keyVal[0])] = val
+	}
+	return p, nil
+}
+
+// Parse builds a filter factory from a description of the filter and its
+// parameters, such as "one-euro:min-cutoff=1,beta=10". Parameters left out
+// take their default value, which are tuned for landmarks in mediapipe's
+// normalized coordinates. Supported filters and their parameters are:
+//
+//	ema             alpha=0.5
+//	one-euro        min-cutoff=1 beta=10 d-cutoff=1
+//	savitzky-golay  window=9 order=2 (window at most 255)
+//	kalman          process-noise=1 measurement-noise=0.0001
+func Parse(spec string) (Factory, error) {
+	name := spec
+	rawParams := ""
+	if i := strings.Index(spec, ":"); i != -1 {
+		name = spec[:i]
+		rawParams = spec[i+1:]
+	}
+	name = strings.TrimSpace(name)
+
+	p, err := parseParams(rawParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var factory Factory
+	switch name {
+	case "ema":
+		alpha := p.get("alpha", 0.5)
+		if alpha <= 0 || alpha > 1 {
+			return nil, fmt.Errorf("ema alpha must be within (0, 1], got %g", alpha)
+		}
+		factory = NewExponentialMovingAverage(alpha)
+
+	case "one-euro":
+		minCutoff := p.get("min-cutoff", 1)
+		beta := p.get("beta", 10)
+		derivativeCutoff := p.get("d-cutoff", 1)
+		if minCutoff <= 0 || derivativeCutoff <= 0 || beta < 0 {
+			return nil, fmt.Errorf("one-euro cutoffs must be positive and beta can not be negative")
+		}
+		factory = NewOneEuro(minCutoff, beta, derivativeCutoff)
+
+	case "savitzky-golay":
+		window := p.get("window", 9)
+		order := p.get("order", 2)
+		if order < 0 || window <= order {
+			return nil, fmt.Errorf("savitzky-golay window must be greater than order, got window %g and order %g", window, order)
+		}
+		if window > maxSavitzkyGolayWindow {
+			return nil, fmt.Errorf("savitzky-golay window can not be larger than %d, got %g", maxSavitzkyGolayWindow, window)
+		}
+		if window != float64(int(window)) || order != float64(int(order)) {
+			return nil, fmt.Errorf("savitzky-golay window and order must be whole numbers")
+		}
+		factory = NewSavitzkyGolay(int(window), int(order))
+
+	case "kalman":
+		processNoise := p.get("process-noise", 1)
+		measurementNoise := p.get("measurement-noise", 0.0001)
+		if processNoise <= 0 || measurementNoise <= 0 {
+			return nil, fmt.Errorf("kalman noise parameters must be positive")
+		}
+		factory = NewKalman(processNoise, measurementNoise)
+
+	default:
+		return nil, fmt.Errorf("unknown filter %q", name)
+	}
+
+	if err := p.unused(name); err != nil {
+		return nil, err
+	}
+	return factory, nil
+}
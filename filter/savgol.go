@@ -0,0 +1,133 @@
+package filter
+
+import "math"
+
+// SavitzkyGolay fits a polynomial through the most recent values of the
+// stream with least squares, and returns the polynomial evaluated at the
+// newest value's time. Since only values that have already arrived are used,
+// the filter can be run as frames stream in. Irregularly spaced values are
+// supported as each value is fit at the time it occurred.
+type SavitzkyGolay struct {
+	// Window is how many of the most recent values are fit.
+	Window int
+
+	// Order is the degree of the polynomial fit through the window. It must
+	// be less than Window.
+	Order int
+
+	times  []float64
+	values []float64
+}
+
+// NewSavitzkyGolay creates a factory for Savitzky-Golay filters with the
+// provided window size and polynomial order.
+func NewSavitzkyGolay(window, order int) Factory {
+	return func() Filter {
+		return &SavitzkyGolay{
+			Window: window,
+			Order:  order,
+			times:  make([]float64, 0, window),
+			values: make([]float64, 0, window),
+		}
+	}
+}
+
+func (sg *SavitzkyGolay) Filter(t, x float64) float64 {
+	if len(sg.times) == sg.Window {
+		sg.times = sg.times[1:]
+		sg.values = sg.values[1:]
+	}
+	sg.times = append(sg.times, t)
+	sg.values = append(sg.values, x)
+
+	order := sg.Order
+	if order > len(sg.times)-1 {
+		order = len(sg.times) - 1
+	}
+
+	// Fitting relative to the newest time keeps the system well conditioned,
+	// and means the fit evaluated at the newest time is just the constant
+	// term.
+	coefficients, ok := fitPolynomial(sg.times, sg.values, t, order)
+	if !ok {
+		return x
+	}
+	return coefficients[0]
+}
+
+// fitPolynomial finds the least squares polynomial of the provided order
+// through the points, with x measured relative to origin. Only the constant
+// term is in the original units of x, the rest are in units of the scaled x.
+func fitPolynomial(xs, ys []float64, origin float64, order int) ([]float64, bool) {
+	size := order + 1
+
+	// Scale x to within [-1, 0] so high powers of small time steps don't
+	// vanish.
+	scale := 0.0
+	for _, x := range xs {
+		scale = math.Max(scale, math.Abs(x-origin))
+	}
+	if scale == 0 {
+		scale = 1
+	}
+
+	// Normal equations (AᵀA)c = Aᵀy as an augmented matrix
+	system := make([][]float64, size)
+	for row := range system {
+		system[row] = make([]float64, size+1)
+	}
+
+	for i := range xs {
+		x := (xs[i] - origin) / scale
+		powers := make([]float64, size*2)
+		powers[0] = 1
+		for p := 1; p < len(powers); p++ {
+			powers[p] = powers[p-1] * x
+		}
+
+		for row := 0; row < size; row++ {
+			for col := 0; col < size; col++ {
+				system[row][col] += powers[row+col]
+			}
+			system[row][size] += powers[row] * ys[i]
+		}
+	}
+
+	return solve(system)
+}
+
+// solve runs gaussian elimination with partial pivoting over the augmented
+// matrix.
+func solve(system [][]float64) ([]float64, bool) {
+	size := len(system)
+	for col := 0; col < size; col++ {
+		pivot := col
+		for row := col + 1; row < size; row++ {
+			if math.Abs(system[row][col]) > math.Abs(system[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(system[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		system[col], system[pivot] = system[pivot], system[col]
+
+		for row := col + 1; row < size; row++ {
+			factor := system[row][col] / system[col][col]
+			for k := col; k <= size; k++ {
+				system[row][k] -= factor * system[col][k]
+			}
+		}
+	}
+
+	solution := make([]float64, size)
+	for row := size - 1; row >= 0; row-- {
+		sum := system[row][size]
+		for col := row + 1; col < size; col++ {
+			sum -= system[row][col] * solution[col]
+		}
+		solution[row] = sum / system[row][row]
+	}
+	return solution, true
+}
@@ -2,10 +2,10 @@ module github.com/recolude/pose-recording
 
 go 1.17
 
-require github.com/recolude/rap v0.0.0-20210826014711-038a9d8c1ec7
-
 require (
-	github.com/EliCDavis/vector v0.0.0-20200616023845-ce88265e47b5 // indirect
-	github.com/golang/protobuf v1.5.2 // indirect
-	google.golang.org/protobuf v1.26.0 // indirect
+	github.com/EliCDavis/vector v0.0.0-20200616023845-ce88265e47b5
+	github.com/recolude/rap v0.0.0-20210826014711-038a9d8c1ec7
+	google.golang.org/protobuf v1.26.0
 )
+
+require github.com/golang/protobuf v1.5.2 // indirect
@@ -0,0 +1,240 @@
+// Package hands converts mediapipe hand landmarks into recolude recordings.
+package hands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/topology"
+	"github.com/recolude/pose-recording/tracking"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/event"
+	"github.com/recolude/rap/format/collection/position"
+	"github.com/recolude/rap/format/metadata"
+)
+
+// MediaPipe is the 21 landmark model reported by mediapipe hands.
+var MediaPipe = topology.MustBuiltin("mediapipe-hands")
+
+// Models are the built in models, by the short names the command line knows
+// them by.
+var Models = map[string]*topology.Topology{
+	"mediapipe": MediaPipe,
+}
+
+type runningData struct {
+	hands            []*handTrack
+	maxMatchDistance float64
+	maxGap           float64
+	smoothing        filter.Factory
+
+	// aabb encompasses every landmark seen so far, used to center the hands
+	// once the entire clip has been seen.
+	aabb *landmark.AABB
+
+	// idPrefix is prepended to the ID of every landmark's recording.
+	idPrefix string
+
+	// model names, styles and connects the landmarks of each hand.
+	model *topology.Topology
+}
+
+// landmarkID is the ID of the recording for a landmark of a hand.
+func (rd *runningData) landmarkID(handIndex, landmarkIndex int) string {
+	return rd.idPrefix + strconv.Itoa(landmarkIndex+(len(rd.model.Landmarks)*handIndex))
+}
+
+func (rd *runningData) handRecording(handIndex int, track *handTrack) format.Recording {
+	captures := track.Centered(rd.aabb)
+
+	childrenRecordings := make([]format.Recording, len(captures))
+	for i, col := range captures {
+		childrenRecordings[i] = format.NewRecording(
+			rd.landmarkID(handIndex, i),
+			rd.model.LandmarkName(i),
+			[]format.CaptureCollection{
+				position.NewCollection(landmark.PositionCollectionName, col),
+			},
+			nil,
+			rd.model.LandmarkMetadata(i),
+			nil,
+			nil,
+		)
+	}
+
+	metadataLines := rd.model.Lines(len(captures), func(landmark int) string {
+		return rd.landmarkID(handIndex, landmark)
+	})
+
+	handedness := track.handedness()
+	handMetadata := metadata.EmptyBlock()
+	handMetadata.Mapping()["recolude-lines"] = metadata.NewMetadataArrayProperty(metadataLines)
+	handMetadata.Mapping()["handedness"] = metadata.NewStringProperty(handedness)
+
+	collections := make([]format.CaptureCollection, 0)
+	if gaps := tracking.FindGaps(track.Seen, rd.maxGap); len(gaps) > 0 {
+		collections = append(collections, event.NewCollection(tracking.CollectionName, tracking.Events(gaps)))
+	}
+
+	name := fmt.Sprintf("Hand %d", handIndex)
+	if handedness != "" {
+		name = fmt.Sprintf("%s Hand %d", handedness, handIndex)
+	}
+
+	return format.NewRecording(
+		fmt.Sprintf("hand-%d", handIndex),
+		name,
+		collections,
+		childrenRecordings,
+		handMetadata,
+		nil,
+		nil,
+	)
+}
+
+func (rd *runningData) toRecording() format.Recording {
+	handRecordings := make([]format.Recording, len(rd.hands))
+	for handIndex, track := range rd.hands {
+		handRecordings[handIndex] = rd.handRecording(handIndex, track)
+	}
+
+	return format.NewRecording(
+		"hands",
+		"Hand Capture Demo",
+		[]format.CaptureCollection{},
+		handRecordings,
+		metadata.EmptyBlock(),
+		nil,
+		nil,
+	)
+}
+
+func (rd *runningData) process(curTime float64, frame []landmark.LandMark) error {
+	if err := tracking.CheckIDs(frame, rd.model); err != nil {
+		return err
+	}
+
+	for _, mark := range frame {
+		rd.aabb.Encompass(mark.X, mark.Y, mark.Z)
+	}
+
+	hands := tracking.GroupBy(frame, func(mark landmark.LandMark) int {
+		return mark.HandID
+	})
+	for i, track := range matchHands(rd.hands, hands, rd.maxMatchDistance) {
+		if track == nil {
+			track = newHandTrack(rd.smoothing, len(rd.model.Landmarks))
+			rd.hands = append(rd.hands, track)
+		}
+		track.add(curTime, hands[i], rd.maxGap)
+	}
+	return nil
+}
+
+// Converter builds recordings out of frames of hand landmarks.
+type Converter struct {
+	// FrameRate is the rate the frames were captured at, used to determine
+	// when a frame occurred if it carries no timestamp of its own.
+	FrameRate float64
+
+	// MaxMatchDistance is how far apart (in the detector's normalized
+	// coordinates) a hand can be from where a hand was last seen and still be
+	// considered the same hand.
+	MaxMatchDistance float64
+
+	// MaxGap is the longest time, in seconds, a hand can go undetected before
+	// it's considered lost. Zero derives the gap from the frame rate.
+	MaxGap float64
+
+	// Smoothing builds the filters used to smooth each hand's landmarks
+	// before they're captured. Nil leaves the landmarks untouched.
+	Smoothing filter.Factory
+
+	// Bounds, if set, is grown to encompass every landmark and used to center
+	// the hands in place of bounds of the converter's own. Sharing bounds
+	// between converters places their recordings in the same space.
+	Bounds *landmark.AABB
+
+	// IDPrefix is prepended to the ID of every landmark's recording, keeping
+	// IDs unique when the recording sits alongside others.
+	IDPrefix string
+
+	// Topology names, styles and connects the landmarks of each hand. Left
+	// nil, landmarks are assumed to come from mediapipe. Frames holding a
+	// landmark ID the topology doesn't define are rejected.
+	Topology *topology.Topology
+}
+
+// NewConverter creates a converter for frames captured at the provided frame
+// rate.
+func NewConverter(frameRate float64) Converter {
+	return Converter{
+		FrameRate:        frameRate,
+		MaxMatchDistance: 0.2,
+		Topology:         MediaPipe,
+	}
+}
+
+func (c Converter) newRunningData() *runningData {
+	aabb := c.Bounds
+	if aabb == nil {
+		aabb = landmark.NewAABB()
+	}
+	model := c.Topology
+	if model == nil {
+		model = MediaPipe
+	}
+	return &runningData{
+		hands:            make([]*handTrack, 0),
+		maxMatchDistance: c.MaxMatchDistance,
+		maxGap:           tracking.ResolveMaxGap(c.MaxGap, c.FrameRate),
+		smoothing:        c.Smoothing,
+		aabb:             aabb,
+		idPrefix:         c.IDPrefix,
+		model:            model,
+	}
+}
+
+// Convert builds a single recording containing every hand found within the
+// frames. Each hand gets its own child recording, labeled by its handedness,
+// which in turn contains a child recording per landmark. Any stretch of time
+// a hand went undetected is marked with tracking events on the hand's
+// recording.
+func (c Converter) Convert(frames []landmark.Frame) (format.Recording, error) {
+	return c.Stream(landmark.NewSliceReader(frames))
+}
+
+// Stream builds the same recording as Convert, but reads frames one at a
+// time, never holding onto more than a single frame.
+func (c Converter) Stream(frames landmark.FrameReader) (format.Recording, error) {
+	builder := c.NewBuilder()
+	if err := landmark.Feed(frames, c.FrameRate, builder.Add); err != nil {
+		return nil, err
+	}
+	return builder.Recording(), nil
+}
+
+// Builder builds a recording one frame at a time, for callers feeding the
+// converter landmarks from a source other than a FrameReader.
+type Builder struct {
+	rd *runningData
+}
+
+// NewBuilder creates a builder that produces the same recording Convert
+// would for the frames added to it.
+func (c Converter) NewBuilder() *Builder {
+	return &Builder{rd: c.newRunningData()}
+}
+
+// Add records the landmarks detected within a frame that occurred at the
+// provided time, in seconds.
+func (b *Builder) Add(curTime float64, marks []landmark.LandMark) error {
+	return b.rd.process(curTime, marks)
+}
+
+// Recording builds the recording out of every frame added so far.
+func (b *Builder) Recording() format.Recording {
+	return b.rd.toRecording()
+}
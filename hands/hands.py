@@ -0,0 +1,83 @@
+import cv2
+import mediapipe as mp
+import os
+import json
+
+mp_drawing = mp.solutions.drawing_utils
+mp_drawing_styles = mp.solutions.drawing_styles
+mp_hands = mp.solutions.hands
+
+
+def video_image_files(dir):
+    num_files = len([name for name in os.listdir(
+        dir) if os.path.isfile(os.path.join(dir, name))])
+    file_paths = []
+
+    for i in range(num_files):
+        file_paths.append("frames/frame_{0}.png".format(str(i+1).zfill(4)))
+
+    return file_paths
+
+
+def process_frames(frames, out_frame_path, out_path, max_num_hands=2):
+    data_out = []
+
+    with mp_hands.Hands(
+            static_image_mode=False,
+            max_num_hands=max_num_hands,
+            model_complexity=1,
+            min_detection_confidence=0.5) as hands:
+
+        for idx, file in enumerate(frames):
+            image = cv2.imread(file)
+            results = hands.process(cv2.cvtColor(image, cv2.COLOR_BGR2RGB))
+
+            if not results.multi_hand_landmarks:
+                continue
+
+            entry = []
+
+            annotated_image = image.copy()
+            hand_index = 0
+            for hand_landmarks, handedness in zip(results.multi_hand_landmarks, results.multi_handedness):
+
+                # Mediapipe assumes the image is mirrored (as from a selfie
+                # camera) when labeling handedness.
+                label = handedness.classification[0].label
+
+                i = 0
+                for mark in hand_landmarks.landmark:
+                    entry.append({
+                        "id": i,
+                        "hand-id": hand_index,
+                        "handedness": label,
+                        "x": mark.x,
+                        "y": mark.y,
+                        "z": mark.z,
+                    })
+                    i += 1
+
+                mp_drawing.draw_landmarks(
+                    annotated_image,
+                    hand_landmarks,
+                    mp_hands.HAND_CONNECTIONS,
+                    mp_drawing_styles.get_default_hand_landmarks_style(),
+                    mp_drawing_styles.get_default_hand_connections_style())
+
+                hand_index += 1
+
+            data_out.append({
+                "frame": idx,
+                "landmarks": entry,
+            })
+
+            out_img_path = os.path.join(
+                out_frame_path, f"frame_{str(idx + 1).zfill(4)}.png")
+            cv2.imwrite(out_img_path, annotated_image)
+
+    with open(out_path, 'w') as outfile:
+        json.dump(data_out, outfile)
+
+
+if __name__ == "__main__":
+    process_frames(video_image_files("frames"), "frames_out", "hands.json")
@@ -0,0 +1,132 @@
+package hands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/tracking"
+)
+
+// hand builds every landmark of a hand detected at x, as the subject with the
+// ID.
+func hand(id int, label string, x float64) []landmark.LandMark {
+	marks := make([]landmark.LandMark, len(MediaPipe.Landmarks))
+	for i := range marks {
+		marks[i] = landmark.LandMark{ID: i, HandID: id, Handedness: label, X: x, Y: float64(i) / 100}
+	}
+	return marks
+}
+
+func frame(hands ...[]landmark.LandMark) landmark.Frame {
+	marks := make([]landmark.LandMark, 0)
+	for _, h := range hands {
+		marks = append(marks, h...)
+	}
+	return landmark.Frame{Landmarks: marks}
+}
+
+func TestConvertTracksHands(t *testing.T) {
+	type want struct {
+		name     string
+		captures int
+		tracking int
+	}
+
+	tests := []struct {
+		name   string
+		frames []landmark.Frame
+		want   []want
+	}{
+		{
+			name: "order swapped between frames",
+			frames: []landmark.Frame{
+				frame(hand(0, "Left", 0.2), hand(1, "Right", 0.8)),
+				frame(hand(0, "Right", 0.8), hand(1, "Left", 0.2)),
+			},
+			want: []want{{"Left Hand 0", 2, 0}, {"Right Hand 1", 2, 0}},
+		},
+		{
+			name: "handedness kept apart when close",
+			frames: []landmark.Frame{
+				frame(hand(0, "Left", 0.5)),
+				frame(hand(0, "Right", 0.5)),
+			},
+			want: []want{{"Left Hand 0", 1, 0}, {"Right Hand 1", 1, 0}},
+		},
+		{
+			name: "unlabeled hands matched by distance",
+			frames: []landmark.Frame{
+				frame(hand(0, "", 0.2)),
+				frame(hand(0, "", 0.25)),
+				frame(hand(0, "", 0.9)),
+			},
+			want: []want{{"Hand 0", 2, 0}, {"Hand 1", 1, 0}},
+		},
+		{
+			name: "lost and regained",
+			frames: []landmark.Frame{
+				frame(hand(0, "Left", 0.2)),
+				frame(),
+				frame(),
+				frame(hand(0, "Left", 0.2)),
+			},
+			want: []want{{"Left Hand 0", 2, 2}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recording, err := NewConverter(30).Convert(tc.frames)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			hands := recording.Recordings()
+			if len(hands) != len(tc.want) {
+				t.Fatalf("got %d hands, want %d", len(hands), len(tc.want))
+			}
+			for i, w := range tc.want {
+				if hands[i].Name() != w.name {
+					t.Fatalf("hand %d: got name %q, want %q", i, hands[i].Name(), w.name)
+				}
+				if got := len(landmark.Positions(hands[i].Recordings()[0])); got != w.captures {
+					t.Fatalf("hand %d: got %d captures, want %d", i, got, w.captures)
+				}
+
+				events := 0
+				for _, collection := range hands[i].CaptureCollections() {
+					if collection.Name() == tracking.CollectionName {
+						events = collection.Length()
+					}
+				}
+				if events != w.tracking {
+					t.Fatalf("hand %d: got %d tracking events, want %d", i, events, w.tracking)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertRejectsUnknownLandmarks(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int
+	}{
+		{"negative", -1},
+		{"past the model", len(MediaPipe.Landmarks)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := []landmark.Frame{
+				frame(hand(0, "Left", 0.2)),
+				{Landmarks: []landmark.LandMark{{ID: tc.id}}},
+			}
+			_, err := NewConverter(30).Convert(frames)
+			if err == nil || !strings.HasPrefix(err.Error(), "frame 2: landmark ID") {
+				t.Fatalf("got error %v, want one naming frame 2's landmark ID", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package hands
+
+import (
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/tracking"
+)
+
+// handTrack is every capture belonging to a single hand as it moves through
+// the clip.
+type handTrack struct {
+	*tracking.Subject
+
+	// labels counts how many times the hand was labeled with each
+	// handedness.
+	labels map[string]int
+}
+
+func newHandTrack(smoothing filter.Factory, landmarks int) *handTrack {
+	return &handTrack{
+		Subject: tracking.NewSubject(smoothing, landmarks),
+		labels:  make(map[string]int),
+	}
+}
+
+func (ht *handTrack) add(curTime float64, hand []landmark.LandMark, maxGap float64) {
+	ht.Subject.Add(curTime, hand, maxGap)
+	if label := handedness(hand); label != "" {
+		ht.labels[label]++
+	}
+}
+
+// handedness is the label the hand was given most often, as the detector can
+// flip its guess for a frame or two.
+func (ht *handTrack) handedness() string {
+	best := ""
+	for label, count := range ht.labels {
+		if count > ht.labels[best] || (count == ht.labels[best] && label < best) {
+			best = label
+		}
+	}
+	return best
+}
+
+// handedness is the label the detector gave the hand within a single frame.
+func handedness(hand []landmark.LandMark) string {
+	for _, mark := range hand {
+		if mark.Handedness != "" {
+			return mark.Handedness
+		}
+	}
+	return ""
+}
+
+// matchHands pairs every hand detected within a frame with the track it most
+// likely belongs to. A hand is only matched to a track of the same
+// handedness, when both are known. Hands further than maxDistance from every
+// available track are left unmatched, represented by a nil track.
+func matchHands(tracks []*handTrack, hands [][]landmark.LandMark, maxDistance float64) []*handTrack {
+	subjects := make([]*tracking.Subject, len(tracks))
+	trackLabels := make([]string, len(tracks))
+	for i, track := range tracks {
+		subjects[i] = track.Subject
+		trackLabels[i] = track.handedness()
+	}
+
+	handLabels := make([]string, len(hands))
+	for i, hand := range hands {
+		handLabels[i] = handedness(hand)
+	}
+
+	sameHandedness := func(hand, track int) bool {
+		return handLabels[hand] == "" || trackLabels[track] == "" || handLabels[hand] == trackLabels[track]
+	}
+
+	matches := make([]*handTrack, len(hands))
+	for handIndex, trackIndex := range tracking.MatchSubjects(subjects, hands, maxDistance, sameHandedness) {
+		if trackIndex != -1 {
+			matches[handIndex] = tracks[trackIndex]
+		}
+	}
+	return matches
+}
@@ -0,0 +1,280 @@
+// Package holistic converts mediapipe holistic landmarks, which report the
+// pose, face and hands of a single person together, into recolude recordings.
+package holistic
+
+import (
+	"fmt"
+
+	"github.com/recolude/pose-recording/face"
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/hands"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/pose"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/metadata"
+)
+
+// The parts a holistic landmark can belong to.
+const (
+	PosePart      = "pose"
+	FacePart      = "face"
+	LeftHandPart  = "left-hand"
+	RightHandPart = "right-hand"
+)
+
+// Landmarks shared between the pose and the other components, used to anchor
+// each component's depth to the pose's.
+const (
+	poseNose       = 0
+	poseLeftWrist  = 15
+	poseRightWrist = 16
+	faceNoseTip    = 1
+	handWrist      = 0
+)
+
+// anchor pairs a landmark of a component with the pose landmark it sits at.
+type anchor struct {
+	component int
+	pose      int
+}
+
+var anchors = map[string]anchor{
+	FacePart:      {component: faceNoseTip, pose: poseNose},
+	LeftHandPart:  {component: handWrist, pose: poseLeftWrist},
+	RightHandPart: {component: handWrist, pose: poseRightWrist},
+}
+
+// handedness is the label and hand ID given to the landmarks of each hand
+// before they're handed off to the hands converter.
+var handedness = map[string]struct {
+	label string
+	id    int
+}{
+	LeftHandPart:  {label: "Left", id: 0},
+	RightHandPart: {label: "Right", id: 1},
+}
+
+type runningData struct {
+	pose  *pose.Builder
+	face  *face.Builder
+	hands *hands.Builder
+
+	// depthOffsets is the last shift applied to the depth of each component
+	// to line it up with the pose, reused for frames where the pose went
+	// undetected.
+	depthOffsets map[string]float64
+
+	// seen is which parts have been detected at least once.
+	seen map[string]bool
+}
+
+// groupByPart splits a frame into the landmarks of each body component.
+func groupByPart(frame []landmark.LandMark) map[string][]landmark.LandMark {
+	parts := make(map[string][]landmark.LandMark)
+	for _, mark := range frame {
+		parts[mark.Part] = append(parts[mark.Part], mark)
+	}
+	return parts
+}
+
+func findLandmark(marks []landmark.LandMark, id int) (landmark.LandMark, bool) {
+	for _, mark := range marks {
+		if mark.ID == id {
+			return mark, true
+		}
+	}
+	return landmark.LandMark{}, false
+}
+
+// anchored shifts the depth of a component's landmarks so its anchor
+// landmark sits at the same depth as the pose landmark it corresponds to.
+// Mediapipe reports the depth of the face and hands relative to their own
+// centers, while x and y are already shared across every component.
+func (rd *runningData) anchored(part string, marks, poseMarks []landmark.LandMark) []landmark.LandMark {
+	a := anchors[part]
+	mark, markOk := findLandmark(marks, a.component)
+	poseMark, poseOk := findLandmark(poseMarks, a.pose)
+	if markOk && poseOk {
+		rd.depthOffsets[part] = poseMark.Z - mark.Z
+	}
+
+	offset := rd.depthOffsets[part]
+	shifted := make([]landmark.LandMark, len(marks))
+	for i, mark := range marks {
+		shifted[i] = mark
+		shifted[i].Z += offset
+	}
+	return shifted
+}
+
+func (rd *runningData) process(curTime float64, frame []landmark.LandMark) error {
+	parts := groupByPart(frame)
+	poseMarks := parts[PosePart]
+
+	if len(poseMarks) > 0 {
+		if err := rd.pose.Add(curTime, poseMarks); err != nil {
+			return fmt.Errorf("%s: %w", PosePart, err)
+		}
+		rd.seen[PosePart] = true
+	}
+
+	if faceMarks := parts[FacePart]; len(faceMarks) > 0 {
+		if err := rd.face.Add(curTime, rd.anchored(FacePart, faceMarks, poseMarks)); err != nil {
+			return fmt.Errorf("%s: %w", FacePart, err)
+		}
+		rd.seen[FacePart] = true
+	}
+
+	handMarks := make([]landmark.LandMark, 0)
+	for _, part := range []string{LeftHandPart, RightHandPart} {
+		if len(parts[part]) == 0 {
+			continue
+		}
+		for _, mark := range rd.anchored(part, parts[part], poseMarks) {
+			mark.Handedness = handedness[part].label
+			mark.HandID = handedness[part].id
+			handMarks = append(handMarks, mark)
+		}
+		rd.seen[part] = true
+	}
+	if len(handMarks) > 0 {
+		if err := rd.hands.Add(curTime, handMarks); err != nil {
+			return fmt.Errorf("hands: %w", err)
+		}
+	}
+	return nil
+}
+
+// component gives the recording built for a body component its place within
+// the holistic recording.
+func component(id, name string, recording format.Recording) format.Recording {
+	return format.NewRecording(
+		id,
+		name,
+		recording.CaptureCollections(),
+		recording.Recordings(),
+		recording.Metadata(),
+		nil,
+		nil,
+	)
+}
+
+func (rd *runningData) toRecording() format.Recording {
+	components := make([]format.Recording, 0, 3)
+	if rd.seen[PosePart] {
+		components = append(components, component("pose", "Pose", rd.pose.Recording()))
+	}
+	if rd.seen[FacePart] {
+		components = append(components, component("face", "Face", rd.face.Recording()))
+	}
+	if rd.seen[LeftHandPart] || rd.seen[RightHandPart] {
+		components = append(components, component("hands", "Hands", rd.hands.Recording()))
+	}
+
+	recordingMetadata := metadata.EmptyBlock()
+	recordingMetadata.Mapping()["recolude-sun-position"] = metadata.NewVector3Property(0, 200, -100)
+	recordingMetadata.Mapping()["recolude-grid"] = metadata.NewStringProperty("false")
+
+	return format.NewRecording(
+		"holistic",
+		"Holistic Capture Demo",
+		[]format.CaptureCollection{},
+		components,
+		recordingMetadata,
+		nil,
+		nil,
+	)
+}
+
+// Converter builds recordings out of frames of holistic landmarks, where
+// every landmark is labeled with the part of the body it belongs to.
+type Converter struct {
+	// FrameRate is the rate the frames were captured at, used to determine
+	// when a frame occurred if it carries no timestamp of its own.
+	FrameRate float64
+
+	// MaxGap is the longest time, in seconds, a component can go undetected
+	// before it's considered lost. Zero derives the gap from the frame rate.
+	MaxGap float64
+
+	// Smoothing builds the filters used to smooth each component's landmarks
+	// before they're captured. Nil leaves the landmarks untouched.
+	Smoothing filter.Factory
+}
+
+// NewConverter creates a converter for frames captured at the provided frame
+// rate.
+func NewConverter(frameRate float64) Converter {
+	return Converter{FrameRate: frameRate}
+}
+
+func (c Converter) newRunningData() *runningData {
+	bounds := landmark.NewAABB()
+
+	poseConverter := pose.NewConverter(c.FrameRate)
+	poseConverter.MaxGap = c.MaxGap
+	poseConverter.Smoothing = c.Smoothing
+	poseConverter.Bounds = bounds
+	poseConverter.IDPrefix = "pose-landmark-"
+
+	faceConverter := face.NewConverter(c.FrameRate)
+	faceConverter.MaxGap = c.MaxGap
+	faceConverter.Smoothing = c.Smoothing
+	faceConverter.Bounds = bounds
+	faceConverter.IDPrefix = "face-landmark-"
+
+	handsConverter := hands.NewConverter(c.FrameRate)
+	handsConverter.MaxGap = c.MaxGap
+	handsConverter.Smoothing = c.Smoothing
+	handsConverter.Bounds = bounds
+	handsConverter.IDPrefix = "hand-landmark-"
+
+	return &runningData{
+		pose:         poseConverter.NewBuilder(),
+		face:         faceConverter.NewBuilder(),
+		hands:        handsConverter.NewBuilder(),
+		depthOffsets: make(map[string]float64),
+		seen:         make(map[string]bool),
+	}
+}
+
+// Convert builds a single recording with a child recording for the pose,
+// face and hands found within the frames. Every component shares the same
+// coordinate space, with the depth of the face and hands anchored to the
+// pose's nose and wrists so everything lines up during playback.
+func (c Converter) Convert(frames []landmark.Frame) (format.Recording, error) {
+	return c.Stream(landmark.NewSliceReader(frames))
+}
+
+// Stream builds the same recording as Convert, but reads frames one at a
+// time, never holding onto more than a single frame.
+func (c Converter) Stream(frames landmark.FrameReader) (format.Recording, error) {
+	builder := c.NewBuilder()
+	if err := landmark.Feed(frames, c.FrameRate, builder.Add); err != nil {
+		return nil, err
+	}
+	return builder.Recording(), nil
+}
+
+// Builder builds a recording one frame at a time, for callers feeding the
+// converter landmarks from a source other than a FrameReader.
+type Builder struct {
+	rd *runningData
+}
+
+// NewBuilder creates a builder that produces the same recording Convert
+// would for the frames added to it.
+func (c Converter) NewBuilder() *Builder {
+	return &Builder{rd: c.newRunningData()}
+}
+
+// Add records the landmarks detected within a frame that occurred at the
+// provided time, in seconds.
+func (b *Builder) Add(curTime float64, marks []landmark.LandMark) error {
+	return b.rd.process(curTime, marks)
+}
+
+// Recording builds the recording out of every frame added so far.
+func (b *Builder) Recording() format.Recording {
+	return b.rd.toRecording()
+}
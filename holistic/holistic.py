@@ -0,0 +1,106 @@
+import cv2
+import mediapipe as mp
+import os
+import json
+
+mp_drawing = mp.solutions.drawing_utils
+mp_drawing_styles = mp.solutions.drawing_styles
+mp_holistic = mp.solutions.holistic
+
+
+def video_image_files(dir):
+    num_files = len([name for name in os.listdir(
+        dir) if os.path.isfile(os.path.join(dir, name))])
+    file_paths = []
+
+    for i in range(num_files):
+        file_paths.append("frames/frame_{0}.png".format(str(i+1).zfill(4)))
+
+    return file_paths
+
+
+def part_entries(part, landmarks):
+    entries = []
+    if not landmarks:
+        return entries
+
+    i = 0
+    for mark in landmarks.landmark:
+        entry = {
+            "id": i,
+            "part": part,
+            "x": mark.x,
+            "y": mark.y,
+            "z": mark.z,
+        }
+        if mark.HasField("visibility"):
+            entry["visibility"] = mark.visibility
+        if mark.HasField("presence"):
+            entry["presence"] = mark.presence
+        entries.append(entry)
+        i += 1
+
+    return entries
+
+
+def process_frames(frames, out_frame_path, out_path):
+    data_out = []
+
+    with mp_holistic.Holistic(
+            static_image_mode=False,
+            model_complexity=1,
+            refine_face_landmarks=True,
+            min_detection_confidence=0.5) as holistic:
+
+        for idx, file in enumerate(frames):
+            image = cv2.imread(file)
+            results = holistic.process(cv2.cvtColor(image, cv2.COLOR_BGR2RGB))
+
+            # Every component is reported in the same normalized image
+            # coordinates, so the pose landmarks are used rather than the
+            # world landmarks.
+            entry = []
+            entry += part_entries("pose", results.pose_landmarks)
+            entry += part_entries("face", results.face_landmarks)
+            entry += part_entries("left-hand", results.left_hand_landmarks)
+            entry += part_entries("right-hand", results.right_hand_landmarks)
+
+            if len(entry) == 0:
+                continue
+
+            data_out.append({
+                "frame": idx,
+                "landmarks": entry,
+            })
+
+            annotated_image = image.copy()
+            mp_drawing.draw_landmarks(
+                annotated_image,
+                results.face_landmarks,
+                mp_holistic.FACEMESH_TESSELATION,
+                landmark_drawing_spec=None,
+                connection_drawing_spec=mp_drawing_styles.get_default_face_mesh_tesselation_style())
+            mp_drawing.draw_landmarks(
+                annotated_image,
+                results.pose_landmarks,
+                mp_holistic.POSE_CONNECTIONS,
+                landmark_drawing_spec=mp_drawing_styles.get_default_pose_landmarks_style())
+            mp_drawing.draw_landmarks(
+                annotated_image,
+                results.left_hand_landmarks,
+                mp_holistic.HAND_CONNECTIONS)
+            mp_drawing.draw_landmarks(
+                annotated_image,
+                results.right_hand_landmarks,
+                mp_holistic.HAND_CONNECTIONS)
+
+            out_img_path = os.path.join(
+                out_frame_path, f"frame_{str(idx + 1).zfill(4)}.png")
+            cv2.imwrite(out_img_path, annotated_image)
+
+    with open(out_path, 'w') as outfile:
+        json.dump(data_out, outfile)
+
+
+if __name__ == "__main__":
+    process_frames(video_image_files("frames"), "frames_out", "holistic.json")
@@ -0,0 +1,183 @@
+package holistic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format"
+)
+
+// landmarkIDs gives the ID of every landmark recording nested within the
+// recording.
+func landmarkIDs(recording format.Recording) []string {
+	ids := make([]string, 0)
+	for _, child := range recording.Recordings() {
+		if _, ok := landmark.ID(child); ok {
+			ids = append(ids, child.ID())
+			continue
+		}
+		ids = append(ids, landmarkIDs(child)...)
+	}
+	return ids
+}
+
+func TestAnchored(t *testing.T) {
+	rd := NewConverter(30).newRunningData()
+
+	tests := []struct {
+		name  string
+		part  string
+		marks []landmark.LandMark
+		pose  []landmark.LandMark
+		want  []float64
+	}{
+		{
+			name:  "face moved to the nose",
+			part:  FacePart,
+			marks: []landmark.LandMark{{ID: 0, Z: 0.5}, {ID: faceNoseTip, Z: 0.3}},
+			pose:  []landmark.LandMark{{ID: poseNose, Z: -0.1}},
+			want:  []float64{0.1, -0.1},
+		},
+		{
+			name:  "last offset reused without a pose",
+			part:  FacePart,
+			marks: []landmark.LandMark{{ID: faceNoseTip, Z: 0.5}},
+			want:  []float64{0.1},
+		},
+		{
+			name:  "hands anchored to their own wrist",
+			part:  RightHandPart,
+			marks: []landmark.LandMark{{ID: handWrist, Z: 0}, {ID: 4, Z: -0.25}},
+			pose:  []landmark.LandMark{{ID: poseLeftWrist, Z: 1}, {ID: poseRightWrist, Z: 2}},
+			want:  []float64{2, 1.75},
+		},
+		{
+			name:  "never anchored left alone",
+			part:  LeftHandPart,
+			marks: []landmark.LandMark{{ID: 4, Z: -0.25}},
+			pose:  []landmark.LandMark{{ID: poseLeftWrist, Z: 1}},
+			want:  []float64{-0.25},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := rd.anchored(tc.part, tc.marks, tc.pose)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d landmarks, want %d", len(got), len(tc.want))
+			}
+			for i, want := range tc.want {
+				if diff := got[i].Z - want; diff > 1e-9 || diff < -1e-9 {
+					t.Fatalf("landmark %d: got depth %f, want %f", i, got[i].Z, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertComponents(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []landmark.Frame
+		want   []string
+	}{
+		{
+			name: "pose only",
+			frames: []landmark.Frame{
+				{Landmarks: []landmark.LandMark{{ID: 0, Part: PosePart}}},
+			},
+			want: []string{"pose"},
+		},
+		{
+			name: "every component",
+			frames: []landmark.Frame{
+				{Landmarks: []landmark.LandMark{
+					{ID: 0, Part: PosePart},
+					{ID: 1, Part: FacePart},
+					{ID: 0, Part: LeftHandPart},
+				}},
+				{Landmarks: []landmark.LandMark{{ID: 0, Part: RightHandPart}}},
+			},
+			want: []string{"pose", "face", "hands"},
+		},
+		{
+			name: "hands without a pose",
+			frames: []landmark.Frame{
+				{Landmarks: []landmark.LandMark{{ID: 0, Part: RightHandPart}}},
+			},
+			want: []string{"hands"},
+		},
+		{
+			name:   "nothing detected",
+			frames: []landmark.Frame{{}},
+			want:   []string{},
+		},
+	}
+
+	prefixes := map[string]string{
+		"pose":  "pose-landmark-",
+		"face":  "face-landmark-",
+		"hands": "hand-landmark-",
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recording, err := NewConverter(30).Convert(tc.frames)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			components := recording.Recordings()
+			if len(components) != len(tc.want) {
+				t.Fatalf("got %d components, want %v", len(components), tc.want)
+			}
+			for i, want := range tc.want {
+				if components[i].ID() != want {
+					t.Fatalf("component %d: got %q, want %q", i, components[i].ID(), want)
+				}
+				if len(landmarkIDs(components[i])) == 0 {
+					t.Fatalf("component %q has no landmarks", want)
+				}
+				for _, id := range landmarkIDs(components[i]) {
+					if !strings.HasPrefix(id, prefixes[want]) {
+						t.Fatalf("component %q: got landmark %q, want the prefix %q", want, id, prefixes[want])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConvertNamesFailingPart(t *testing.T) {
+	tests := []struct {
+		name    string
+		marks   []landmark.LandMark
+		wantErr string
+	}{
+		{
+			name:    "pose",
+			marks:   []landmark.LandMark{{ID: 33, Part: PosePart}},
+			wantErr: "pose: landmark ID 33",
+		},
+		{
+			name:    "face",
+			marks:   []landmark.LandMark{{ID: -1, Part: FacePart}},
+			wantErr: "face: landmark ID -1",
+		},
+		{
+			name:    "hands",
+			marks:   []landmark.LandMark{{ID: 21, Part: LeftHandPart}},
+			wantErr: "hands: landmark ID 21",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewConverter(30).NewBuilder().Add(0, tc.marks)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want one starting with %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package landmark
+
+import (
+	"math"
+
+	"github.com/EliCDavis/vector"
+)
+
+// AABB is an axis aligned bounding box, used to find the center of a set of
+// landmarks so they can be shifted to sit around the origin.
+type AABB struct {
+	min    vector.Vector3
+	max    vector.Vector3
+	center *vector.Vector3
+}
+
+// NewAABB creates an empty bounding box that encompasses nothing.
+func NewAABB() *AABB {
+	return &AABB{
+		min: vector.NewVector3(math.Inf(1), math.Inf(1), math.Inf(1)),
+		max: vector.NewVector3(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+	}
+}
+
+// Center is the point halfway between the box's min and max.
+func (aabb *AABB) Center() vector.Vector3 {
+	if aabb.center == nil {
+		center := aabb.max.Add(aabb.min).DivByConstant(2)
+		aabb.center = &center
+	}
+	return *aabb.center
+}
+
+// CenterPos shifts the point by the center of the box.
+func (aabb *AABB) CenterPos(x, y, z float64) vector.Vector3 {
+	if aabb.center == nil {
+		center := aabb.max.Add(aabb.min).DivByConstant(2)
+		aabb.center = &center
+	}
+	return vector.NewVector3(x, y, z).Sub(*aabb.center)
+}
+
+// Encompass grows the box to contain the point.
+func (aabb *AABB) Encompass(x, y, z float64) {
+	aabb.center = nil
+	aabb.min = vector.NewVector3(
+		math.Min(aabb.min.X(), x),
+		math.Min(aabb.min.Y(), y),
+		math.Min(aabb.min.Z(), z),
+	)
+
+	aabb.max = vector.NewVector3(
+		math.Max(aabb.max.X(), x),
+		math.Max(aabb.max.Y(), y),
+		math.Max(aabb.max.Z(), z),
+	)
+}
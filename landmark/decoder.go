@@ -0,0 +1,135 @@
+package landmark
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// FrameReader reads frames one at a time, returning io.EOF once every frame
+// has been read.
+type FrameReader interface {
+	Read() (Frame, error)
+}
+
+// Decoder streams frames out of a json array of frames, decoding a single
+// frame at a time so the entire file never has to be held in memory.
+type Decoder struct {
+	dec     *json.Decoder
+	started bool
+	done    bool
+}
+
+// NewDecoder creates a decoder that reads frames from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		dec: json.NewDecoder(bufio.NewReader(r)),
+	}
+}
+
+func (d *Decoder) expectDelim(delim json.Delim) error {
+	token, err := d.dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %q but found %v", delim, token)
+	}
+	return nil
+}
+
+// Read decodes the next frame within the array.
+func (d *Decoder) Read() (Frame, error) {
+	if d.done {
+		return Frame{}, io.EOF
+	}
+
+	if !d.started {
+		d.started = true
+		if err := d.expectDelim('['); err != nil {
+			return Frame{}, err
+		}
+	}
+
+	if !d.dec.More() {
+		d.done = true
+		if err := d.expectDelim(']'); err != nil {
+			return Frame{}, err
+		}
+		return Frame{}, io.EOF
+	}
+
+	var frame Frame
+	if err := d.dec.Decode(&frame); err != nil {
+		return Frame{}, err
+	}
+	return frame, nil
+}
+
+// LinesDecoder streams frames out of JSON Lines (NDJSON), where every line is
+// a single frame object. This allows detectors to append frames to the end of
+// a file as they're processed.
+type LinesDecoder struct {
+	dec    *json.Decoder
+	frames int
+}
+
+// NewLinesDecoder creates a decoder that reads a frame per line from r.
+func NewLinesDecoder(r io.Reader) *LinesDecoder {
+	return &LinesDecoder{
+		dec: json.NewDecoder(bufio.NewReader(r)),
+	}
+}
+
+// Read decodes the next frame.
+func (d *LinesDecoder) Read() (Frame, error) {
+	var frame Frame
+	if err := d.dec.Decode(&frame); err != nil {
+		if err == io.EOF {
+			return Frame{}, io.EOF
+		}
+		return Frame{}, fmt.Errorf("frame %d: %w", d.frames+1, err)
+	}
+	d.frames++
+	return frame, nil
+}
+
+type emptyReader struct{}
+
+func (emptyReader) Read() (Frame, error) {
+	return Frame{}, io.EOF
+}
+
+// NewReader determines whether r contains a json array of frames or JSON
+// Lines, and creates the appropriate decoder for it.
+func NewReader(r io.Reader) (FrameReader, error) {
+	buffered := bufio.NewReader(r)
+	for {
+		b, err := buffered.Peek(1)
+		if err == io.EOF {
+			return emptyReader{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			buffered.Discard(1)
+
+		case '[':
+			return NewDecoder(buffered), nil
+
+		case '{':
+			return NewLinesDecoder(buffered), nil
+
+		default:
+			return nil, fmt.Errorf("unrecognized landmark format, expected a json array or json lines but found %q", b[0])
+		}
+	}
+}
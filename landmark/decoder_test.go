@@ -0,0 +1,132 @@
+package landmark
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// readAll reads every frame out of the reader, returning the number of
+// landmarks within each.
+func readAll(r FrameReader) ([]int, error) {
+	counts := make([]int, 0)
+	for {
+		frame, err := r.Read()
+		if err == io.EOF {
+			return counts, nil
+		}
+		if err != nil {
+			return counts, err
+		}
+		counts = append(counts, len(frame.Landmarks))
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:  "array of frames",
+			input: `[{"frame": 0, "landmarks": [{"id": 0}]}, {"frame": 1, "landmarks": [{"id": 0}, {"id": 1}]}]`,
+			want:  []int{1, 2},
+		},
+		{
+			name:  "array of bare landmark arrays",
+			input: `[[{"id": 0}, {"id": 1}], [{"id": 0}]]`,
+			want:  []int{2, 1},
+		},
+		{
+			name:  "leading whitespace",
+			input: "\n\t  [{\"landmarks\": [{\"id\": 0}]}]",
+			want:  []int{1},
+		},
+		{
+			name:  "json lines",
+			input: "{\"frame\": 0, \"landmarks\": [{\"id\": 0}]}\n{\"frame\": 1, \"landmarks\": []}\n",
+			want:  []int{1, 0},
+		},
+		{
+			name:  "json lines without a trailing newline",
+			input: `{"landmarks": [{"id": 0}, {"id": 1}, {"id": 2}]}`,
+			want:  []int{3},
+		},
+		{
+			name:  "empty array",
+			input: `[]`,
+			want:  []int{},
+		},
+		{
+			name:  "empty input",
+			input: "",
+			want:  []int{},
+		},
+		{
+			name:  "whitespace only",
+			input: "  \n ",
+			want:  []int{},
+		},
+		{
+			name:    "unknown format",
+			input:   "frame,x,y,z",
+			wantErr: true,
+		},
+		{
+			name:    "truncated array",
+			input:   `[{"landmarks": [{"id": 0}]}`,
+			want:    []int{1},
+			wantErr: true,
+		},
+		{
+			name:    "bad json line",
+			input:   "{\"landmarks\": []}\n{\"landmarks\": \n",
+			want:    []int{0},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewReader(strings.NewReader(tc.input))
+			if err == nil {
+				var got []int
+				got, err = readAll(r)
+				if len(got) != len(tc.want) {
+					t.Fatalf("got frames of %v landmarks, want %v", got, tc.want)
+				}
+				for i := range got {
+					if got[i] != tc.want[i] {
+						t.Fatalf("got frames of %v landmarks, want %v", got, tc.want)
+					}
+				}
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinesDecoderNamesBadFrame(t *testing.T) {
+	d := NewLinesDecoder(strings.NewReader("{\"landmarks\": []}\n{\"landmarks\": []}\n{bad}\n"))
+	_, err := readAll(d)
+	if err == nil || !strings.HasPrefix(err.Error(), "frame 3:") {
+		t.Fatalf("got error %v, want one naming frame 3", err)
+	}
+}
+
+func TestDecoderKeepsReturningEOF(t *testing.T) {
+	d := NewDecoder(strings.NewReader(`[{"landmarks": []}]`))
+	if _, err := d.Read(); err != nil {
+		t.Fatalf("got error %v reading the frame", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := d.Read(); err != io.EOF {
+			t.Fatalf("read %d past the end: got %v, want io.EOF", i+1, err)
+		}
+	}
+}
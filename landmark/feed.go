@@ -0,0 +1,46 @@
+package landmark
+
+import (
+	"fmt"
+	"io"
+)
+
+// SliceReader reads frames out of a slice already held in memory.
+type SliceReader struct {
+	frames []Frame
+	next   int
+}
+
+// NewSliceReader creates a reader that reads each of the frames in order.
+func NewSliceReader(frames []Frame) *SliceReader {
+	return &SliceReader{frames: frames}
+}
+
+// Read returns the next frame within the slice.
+func (r *SliceReader) Read() (Frame, error) {
+	if r.next >= len(r.frames) {
+		return Frame{}, io.EOF
+	}
+	frame := r.frames[r.next]
+	r.next++
+	return frame, nil
+}
+
+// Feed reads every frame out of the reader and hands its landmarks to add,
+// along with when the frame occurred. Frames that carry no timestamp of their
+// own are timed with the frame rate. Feeding stops at the first error, from
+// either reading or adding a frame.
+func Feed(frames FrameReader, fps float64, add func(curTime float64, marks []LandMark) error) error {
+	for i := 0; ; i++ {
+		frame, err := frames.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := add(frame.Timestamp(i, fps), frame.Landmarks); err != nil {
+			return fmt.Errorf("frame %d: %w", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package landmark
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFeed(t *testing.T) {
+	errTooFar := errors.New("too far")
+
+	tests := []struct {
+		name    string
+		frames  []Frame
+		fps     float64
+		fail    func(curTime float64) error
+		want    []float64
+		wantErr string
+	}{
+		{
+			name:   "timed by frame rate",
+			frames: []Frame{{}, {}, {}},
+			fps:    4,
+			want:   []float64{0, 0.25, 0.5},
+		},
+		{
+			name:   "own timestamps kept",
+			frames: []Frame{{Time: floatPointer(1)}, {Index: intPointer(10)}, {}},
+			fps:    10,
+			want:   []float64{1, 1, 0.2},
+		},
+		{
+			name:   "no frames",
+			frames: []Frame{},
+			fps:    30,
+			want:   []float64{},
+		},
+		{
+			name:   "stops at the first error",
+			frames: []Frame{{}, {}, {}},
+			fps:    1,
+			fail: func(curTime float64) error {
+				if curTime >= 1 {
+					return errTooFar
+				}
+				return nil
+			},
+			want:    []float64{0, 1},
+			wantErr: "frame 2: too far",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := make([]float64, 0)
+			err := Feed(NewSliceReader(tc.frames), tc.fps, func(curTime float64, marks []LandMark) error {
+				got = append(got, curTime)
+				if tc.fail != nil {
+					return tc.fail(curTime)
+				}
+				return nil
+			})
+
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr || !errors.Is(err, errTooFar) {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("got frames at %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got frames at %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFeedReturnsReadErrors(t *testing.T) {
+	err := Feed(NewLinesDecoder(strings.NewReader("{\"landmarks\": []}\n{bad}\n")), 30, func(float64, []LandMark) error {
+		return nil
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "frame 2:") {
+		t.Fatalf("got error %v, want one naming frame 2", err)
+	}
+}
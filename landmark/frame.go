@@ -0,0 +1,82 @@
+package landmark
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Frame is every landmark detected within a single frame of the source video.
+type Frame struct {
+	// Index is which frame of the source video the landmarks were detected
+	// in, if known.
+	Index *int `json:"frame,omitempty"`
+
+	// Time is when, in seconds, the frame occurred within the source video,
+	// if known.
+	Time *float64 `json:"t,omitempty"`
+
+	Landmarks []LandMark `json:"landmarks"`
+}
+
+// Timestamp determines when the frame occurred within the source video.
+// Explicit timestamps are preferred, followed by the source frame index. If
+// the frame carries neither, the time is derived from the frame's position
+// within the sequence of frames read.
+func (f Frame) Timestamp(sequence int, fps float64) float64 {
+	if f.Time != nil {
+		return *f.Time
+	}
+
+	if f.Index != nil {
+		return float64(*f.Index) / fps
+	}
+
+	return float64(sequence) / fps
+}
+
+// Subject is every landmark belonging to a single subject (such as one of
+// many faces or hands) within a frame.
+type Subject struct {
+	ID int `json:"id"`
+
+	// Label further identifies the subject, such as a hand's handedness.
+	Label string `json:"label,omitempty"`
+
+	Landmarks []LandMark `json:"landmarks"`
+}
+
+// UnmarshalJSON accepts a frame object, or the original format of a bare
+// array of landmarks which carries no timing information. Frame objects may
+// group their landmarks by subject, in which case every subject's landmarks
+// are appended to the frame's landmarks with their FaceID and HandID set to
+// the subject's ID, and their Handedness set to the subject's label.
+func (f *Frame) UnmarshalJSON(b []byte) error {
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		*f = Frame{}
+		return json.Unmarshal(trimmed, &f.Landmarks)
+	}
+
+	// Alias to avoid recursing back into this function
+	type frame Frame
+	var out struct {
+		frame
+		Subjects []Subject `json:"subjects"`
+	}
+	if err := json.Unmarshal(trimmed, &out); err != nil {
+		return err
+	}
+
+	*f = Frame(out.frame)
+	for _, subject := range out.Subjects {
+		for _, mark := range subject.Landmarks {
+			mark.FaceID = subject.ID
+			mark.HandID = subject.ID
+			if subject.Label != "" {
+				mark.Handedness = subject.Label
+			}
+			f.Landmarks = append(f.Landmarks, mark)
+		}
+	}
+	return nil
+}
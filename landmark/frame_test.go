@@ -0,0 +1,119 @@
+package landmark
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFrameTimestamp(t *testing.T) {
+	index := 12
+	time := 1.25
+
+	tests := []struct {
+		name     string
+		frame    Frame
+		sequence int
+		fps      float64
+		want     float64
+	}{
+		{"sequence", Frame{}, 3, 30, 0.1},
+		{"index over sequence", Frame{Index: &index}, 3, 30, 0.4},
+		{"time over index", Frame{Index: &index, Time: &time}, 3, 30, 1.25},
+		{"time alone", Frame{Time: &time}, 0, 60, 1.25},
+		{"first frame", Frame{}, 0, 24, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.frame.Timestamp(tc.sequence, tc.fps); got != tc.want {
+				t.Fatalf("got %g, want %g", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalJSON(t *testing.T) {
+	type want struct {
+		id         int
+		face       int
+		hand       int
+		handedness string
+	}
+
+	tests := []struct {
+		name      string
+		json      string
+		wantIndex *int
+		wantTime  *float64
+		want      []want
+	}{
+		{
+			name: "bare array",
+			json: `[{"id": 0, "x": 1, "y": 2, "z": 3}, {"id": 1, "x": 4, "y": 5, "z": 6}]`,
+			want: []want{{id: 0}, {id: 1}},
+		},
+		{
+			name:      "frame object",
+			json:      `{"frame": 7, "landmarks": [{"id": 4, "face-id": 2, "hand-id": 1}]}`,
+			wantIndex: intPointer(7),
+			want:      []want{{id: 4, face: 2, hand: 1}},
+		},
+		{
+			name:     "timestamp",
+			json:     `{"t": 0.5, "landmarks": []}`,
+			wantTime: floatPointer(0.5),
+			want:     []want{},
+		},
+		{
+			name: "subjects",
+			json: `{"subjects": [
+				{"id": 1, "label": "Left", "landmarks": [{"id": 0}, {"id": 1}]},
+				{"id": 3, "landmarks": [{"id": 0, "handedness": "Right"}]}
+			]}`,
+			want: []want{
+				{id: 0, face: 1, hand: 1, handedness: "Left"},
+				{id: 1, face: 1, hand: 1, handedness: "Left"},
+				{id: 0, face: 3, hand: 3, handedness: "Right"},
+			},
+		},
+		{
+			name: "subjects alongside landmarks",
+			json: `{"landmarks": [{"id": 5}], "subjects": [{"id": 2, "landmarks": [{"id": 6}]}]}`,
+			want: []want{{id: 5}, {id: 6, face: 2, hand: 2}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var frame Frame
+			if err := json.Unmarshal([]byte(tc.json), &frame); err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			if (frame.Index == nil) != (tc.wantIndex == nil) || (frame.Index != nil && *frame.Index != *tc.wantIndex) {
+				t.Fatalf("got index %v, want %v", frame.Index, tc.wantIndex)
+			}
+			if (frame.Time == nil) != (tc.wantTime == nil) || (frame.Time != nil && *frame.Time != *tc.wantTime) {
+				t.Fatalf("got time %v, want %v", frame.Time, tc.wantTime)
+			}
+
+			if len(frame.Landmarks) != len(tc.want) {
+				t.Fatalf("got %d landmarks, want %d", len(frame.Landmarks), len(tc.want))
+			}
+			for i, w := range tc.want {
+				mark := frame.Landmarks[i]
+				if mark.ID != w.id || mark.FaceID != w.face || mark.HandID != w.hand || mark.Handedness != w.handedness {
+					t.Fatalf("landmark %d: got %+v, want %+v", i, mark, w)
+				}
+			}
+		})
+	}
+}
+
+func intPointer(v int) *int {
+	return &v
+}
+
+func floatPointer(v float64) *float64 {
+	return &v
+}
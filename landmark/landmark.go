@@ -0,0 +1,42 @@
+// Package landmark contains the data model shared by every converter for
+// landmarks reported by mediapipe.
+package landmark
+
+import "fmt"
+
+// LandMark is a single point reported by a landmark detector.
+type LandMark struct {
+	X  float64 `json:"x"`
+	Y  float64 `json:"y"`
+	Z  float64 `json:"z"`
+	ID int     `json:"id"`
+
+	// FaceID is which face within the frame the landmark belongs to, for
+	// detectors capable of finding more than one face at a time.
+	FaceID int `json:"face-id"`
+
+	// HandID is which hand within the frame the landmark belongs to, for
+	// detectors capable of finding more than one hand at a time.
+	HandID int `json:"hand-id"`
+
+	// Handedness is which hand ("Left" or "Right") the landmark belongs to,
+	// if the detector reports it.
+	Handedness string `json:"handedness,omitempty"`
+
+	// Part is which body component ("pose", "face", "left-hand" or
+	// "right-hand") the landmark belongs to, for detectors like mediapipe
+	// holistic that report several components together.
+	Part string `json:"part,omitempty"`
+
+	// Visibility is the likelihood, within [0, 1], of the landmark being
+	// visible and not occluded within the frame, if the detector reports it.
+	Visibility *float64 `json:"visibility,omitempty"`
+
+	// Presence is the likelihood, within [0, 1], of the landmark being
+	// present within the frame, if the detector reports it.
+	Presence *float64 `json:"presence,omitempty"`
+}
+
+func (lm LandMark) String() string {
+	return fmt.Sprintf("%d: %f, %f, %f", lm.ID, lm.X, lm.Y, lm.Z)
+}
@@ -0,0 +1,121 @@
+package landmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// openPoseFrameIndex pulls the frame index OpenPose embeds within the names
+// of the files it writes, such as video_000000000012_keypoints.json.
+var openPoseFrameIndex = regexp.MustCompile(`(\d+)_keypoints\.json$`)
+
+type openPosePerson struct {
+	PoseKeypoints2D []float64 `json:"pose_keypoints_2d"`
+}
+
+type openPoseFile struct {
+	People []openPosePerson `json:"people"`
+}
+
+// OpenPoseReader reads the pose keypoints OpenPose writes to a directory, one
+// *_keypoints.json file per frame.
+type OpenPoseReader struct {
+	files  []string
+	next   int
+	width  float64
+	height float64
+}
+
+// NewOpenPoseReader creates a reader for every keypoint file within the
+// directory, read in order of their names. Keypoints are in pixels, and are
+// divided by the width and height of the source video so they share
+// mediapipe's normalized image coordinates.
+func NewOpenPoseReader(dir string, width, height float64) (*OpenPoseReader, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("image size must be greater than 0, got %gx%g", width, height)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*_keypoints.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no *_keypoints.json files found within %s", dir)
+	}
+	sort.Strings(files)
+
+	return &OpenPoseReader{
+		files:  files,
+		width:  width,
+		height: height,
+	}, nil
+}
+
+// Read decodes the next keypoint file a person was found in. Only the first
+// person within the file is read. Keypoints OpenPose failed to find, reported
+// with a confidence of 0, are left out of the frame, and the confidence of
+// every other keypoint is carried as its visibility. Frames are indexed by
+// the number within the file's name.
+func (r *OpenPoseReader) Read() (Frame, error) {
+	for r.next < len(r.files) {
+		path := r.files[r.next]
+		r.next++
+
+		frame, err := r.readFile(path)
+		if err != nil {
+			return Frame{}, err
+		}
+		if len(frame.Landmarks) > 0 {
+			return frame, nil
+		}
+	}
+	return Frame{}, io.EOF
+}
+
+func (r *OpenPoseReader) readFile(path string) (Frame, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Frame{}, err
+	}
+
+	file := openPoseFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Frame{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	frame := Frame{Landmarks: make([]LandMark, 0)}
+	if match := openPoseFrameIndex.FindStringSubmatch(filepath.Base(path)); match != nil {
+		if index, err := strconv.Atoi(match[1]); err == nil {
+			frame.Index = &index
+		}
+	}
+
+	if len(file.People) == 0 {
+		return frame, nil
+	}
+
+	keypoints := file.People[0].PoseKeypoints2D
+	if len(keypoints)%3 != 0 {
+		return Frame{}, fmt.Errorf("%s: pose_keypoints_2d has %d values, expected triplets of x, y and confidence", filepath.Base(path), len(keypoints))
+	}
+
+	for i := 0; i < len(keypoints); i += 3 {
+		confidence := keypoints[i+2]
+		if confidence == 0 {
+			continue
+		}
+		frame.Landmarks = append(frame.Landmarks, LandMark{
+			ID:         i / 3,
+			X:          keypoints[i] / r.width,
+			Y:          keypoints[i+1] / r.height,
+			Visibility: &confidence,
+		})
+	}
+	return frame, nil
+}
@@ -0,0 +1,151 @@
+package landmark
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func writeKeypoints(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOpenPoseReader(t *testing.T) {
+	type want struct {
+		index     int
+		ids       []int
+		x         float64
+		y         float64
+		confident float64
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []want
+		wantErr bool
+	}{
+		{
+			name: "frames read in order",
+			files: map[string]string{
+				"clip_000000000002_keypoints.json": `{"people": [{"pose_keypoints_2d": [64, 48, 0.5]}]}`,
+				"clip_000000000000_keypoints.json": `{"people": [{"pose_keypoints_2d": [320, 240, 0.9, 10, 20, 0.8]}]}`,
+			},
+			want: []want{
+				{index: 0, ids: []int{0, 1}, x: 0.5, y: 0.5, confident: 0.9},
+				{index: 2, ids: []int{0}, x: 0.1, y: 0.1, confident: 0.5},
+			},
+		},
+		{
+			name: "missed keypoints left out",
+			files: map[string]string{
+				"clip_000000000005_keypoints.json": `{"people": [{"pose_keypoints_2d": [0, 0, 0, 320, 240, 0.7, 0, 0, 0]}]}`,
+			},
+			want: []want{{index: 5, ids: []int{1}, x: 0.5, y: 0.5, confident: 0.7}},
+		},
+		{
+			name: "frames without people skipped",
+			files: map[string]string{
+				"clip_000000000000_keypoints.json": `{"people": []}`,
+				"clip_000000000001_keypoints.json": `{"people": [{"pose_keypoints_2d": [0, 0, 0]}]}`,
+				"clip_000000000002_keypoints.json": `{"people": [{"pose_keypoints_2d": [640, 480, 1]}]}`,
+			},
+			want: []want{{index: 2, ids: []int{0}, x: 1, y: 1, confident: 1}},
+		},
+		{
+			name: "only the first person read",
+			files: map[string]string{
+				"clip_000000000000_keypoints.json": `{"people": [{"pose_keypoints_2d": [320, 0, 1]}, {"pose_keypoints_2d": [0, 240, 1, 0, 0, 1]}]}`,
+			},
+			want: []want{{index: 0, ids: []int{0}, x: 0.5, y: 0, confident: 1}},
+		},
+		{
+			name: "keypoints not in triplets",
+			files: map[string]string{
+				"clip_000000000000_keypoints.json": `{"people": [{"pose_keypoints_2d": [320, 240]}]}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad json",
+			files: map[string]string{
+				"clip_000000000000_keypoints.json": `{"people": [`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeKeypoints(t, dir, tc.files)
+
+			r, err := NewOpenPoseReader(dir, 640, 480)
+			if err != nil {
+				t.Fatalf("got error %v creating the reader", err)
+			}
+
+			for i, w := range tc.want {
+				frame, err := r.Read()
+				if err != nil {
+					t.Fatalf("frame %d: got error %v", i, err)
+				}
+				if frame.Index == nil || *frame.Index != w.index {
+					t.Fatalf("frame %d: got index %v, want %d", i, frame.Index, w.index)
+				}
+				if len(frame.Landmarks) != len(w.ids) {
+					t.Fatalf("frame %d: got %d landmarks, want %d", i, len(frame.Landmarks), len(w.ids))
+				}
+				for m, id := range w.ids {
+					if frame.Landmarks[m].ID != id {
+						t.Fatalf("frame %d landmark %d: got ID %d, want %d", i, m, frame.Landmarks[m].ID, id)
+					}
+				}
+				first := frame.Landmarks[0]
+				if first.X != w.x || first.Y != w.y || first.Visibility == nil || *first.Visibility != w.confident {
+					t.Fatalf("frame %d: got %v with visibility %v, want %+v", i, first, first.Visibility, w)
+				}
+			}
+
+			_, err = r.Read()
+			if tc.wantErr {
+				if err == nil || err == io.EOF {
+					t.Fatalf("got %v, want an error", err)
+				}
+				return
+			}
+			if err != io.EOF {
+				t.Fatalf("got %v after the last frame, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestNewOpenPoseReaderErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		width  float64
+		height float64
+	}{
+		{"no keypoint files", map[string]string{"notes.json": "{}"}, 640, 480},
+		{"zero width", map[string]string{"a_000000000000_keypoints.json": "{}"}, 0, 480},
+		{"negative height", map[string]string{"a_000000000000_keypoints.json": "{}"}, 640, -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeKeypoints(t, dir, tc.files)
+			if _, err := NewOpenPoseReader(dir, tc.width, tc.height); err == nil {
+				t.Fatalf("got no error, want one")
+			}
+		})
+	}
+}
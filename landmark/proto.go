@@ -0,0 +1,252 @@
+package landmark
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers shared by mediapipe's Landmark and NormalizedLandmark
+// messages.
+const (
+	protoFieldX          protowire.Number = 1
+	protoFieldY          protowire.Number = 2
+	protoFieldZ          protowire.Number = 3
+	protoFieldVisibility protowire.Number = 4
+	protoFieldPresence   protowire.Number = 5
+)
+
+// protoFieldList is the repeated field of LandmarkList and
+// NormalizedLandmarkList holding each landmark, as well as the repeated field
+// of the list collections holding each list.
+const protoFieldList protowire.Number = 1
+
+// maxProtoMessageSize is the largest message the decoder will read. A frame
+// of many face meshes is still well under a megabyte, so anything larger is
+// taken to be a corrupt length prefix or a file that isn't protobuf at all.
+const maxProtoMessageSize = 64 << 20
+
+// ProtoDecoder streams frames out of length-delimited mediapipe landmark
+// protobufs, as written by SerializeDelimitedToOstream or writeDelimitedTo.
+// Each message within the stream is a single frame, and may be a LandmarkList,
+// NormalizedLandmarkList, or a collection of either when a frame contains
+// multiple subjects (such as many faces). Landmark IDs are their index within
+// their list, and FaceIDs and HandIDs are the index of the list within the
+// collection.
+//
+// Protobufs carry no timing information, so frames are timed by their
+// position within the stream.
+type ProtoDecoder struct {
+	r      *bufio.Reader
+	frames int
+
+	// remaining is how many bytes are left to read, or -1 if it can't be
+	// known ahead of time.
+	remaining int64
+
+	// message is reused between frames to hold each message as it's read.
+	message []byte
+}
+
+// NewProtoDecoder creates a decoder that reads a frame per message from r.
+func NewProtoDecoder(r io.Reader) *ProtoDecoder {
+	return &ProtoDecoder{
+		r:         bufio.NewReader(r),
+		remaining: remainingBytes(r),
+	}
+}
+
+// remainingBytes is how many bytes are left to read from r, if r is a regular
+// file, and -1 otherwise.
+func remainingBytes(r io.Reader) int64 {
+	f, ok := r.(*os.File)
+	if !ok {
+		return -1
+	}
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return -1
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
+	}
+	return info.Size() - offset
+}
+
+// Read decodes the next message within the stream.
+func (d *ProtoDecoder) Read() (Frame, error) {
+	size, err := binary.ReadUvarint(d.r)
+	if err == io.EOF {
+		return Frame{}, io.EOF
+	}
+	if err != nil {
+		return Frame{}, fmt.Errorf("frame %d: %w", d.frames+1, err)
+	}
+
+	if size > maxProtoMessageSize {
+		return Frame{}, fmt.Errorf("frame %d: message of %d bytes exceeds the limit of %d bytes, the input may not be length-delimited protobuf", d.frames+1, size, maxProtoMessageSize)
+	}
+	if d.remaining >= 0 {
+		d.remaining -= int64(protowire.SizeVarint(size))
+		if int64(size) > d.remaining {
+			return Frame{}, fmt.Errorf("frame %d: message of %d bytes runs past the end of the input, only %d bytes remain", d.frames+1, size, d.remaining)
+		}
+		d.remaining -= int64(size)
+	}
+
+	if uint64(cap(d.message)) < size {
+		d.message = make([]byte, size)
+	}
+	message := d.message[:size]
+	if _, err := io.ReadFull(d.r, message); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Frame{}, fmt.Errorf("frame %d: %w", d.frames+1, err)
+	}
+
+	frame, err := parseProtoFrame(message)
+	if err != nil {
+		return Frame{}, fmt.Errorf("frame %d: %w", d.frames+1, err)
+	}
+	d.frames++
+	return frame, nil
+}
+
+// repeatedEntries pulls out every entry of the repeated message field used by
+// both the landmark lists and their collections.
+func repeatedEntries(message []byte) ([][]byte, error) {
+	entries := make([][]byte, 0)
+	for len(message) > 0 {
+		num, typ, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+
+		if num == protoFieldList && typ == protowire.BytesType {
+			entry, n := protowire.ConsumeBytes(message)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			entries = append(entries, entry)
+			message = message[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+	}
+	return entries, nil
+}
+
+// isList determines whether the entry is a landmark list, as opposed to a
+// single landmark, by whether it's made up of nested messages.
+func isList(entry []byte) bool {
+	num, typ, n := protowire.ConsumeTag(entry)
+	return n > 0 && num == protoFieldList && typ == protowire.BytesType
+}
+
+func parseProtoFrame(message []byte) (Frame, error) {
+	entries, err := repeatedEntries(message)
+	if err != nil {
+		return Frame{}, err
+	}
+
+	collection := false
+	for _, entry := range entries {
+		if len(entry) > 0 {
+			collection = isList(entry)
+			break
+		}
+	}
+
+	frame := Frame{Landmarks: make([]LandMark, 0)}
+	if !collection {
+		marks, err := parseProtoLandmarks(entries, 0)
+		if err != nil {
+			return Frame{}, err
+		}
+		frame.Landmarks = marks
+		return frame, nil
+	}
+
+	for subjectID, list := range entries {
+		landmarkEntries, err := repeatedEntries(list)
+		if err != nil {
+			return Frame{}, err
+		}
+
+		marks, err := parseProtoLandmarks(landmarkEntries, subjectID)
+		if err != nil {
+			return Frame{}, err
+		}
+		frame.Landmarks = append(frame.Landmarks, marks...)
+	}
+	return frame, nil
+}
+
+func parseProtoLandmarks(entries [][]byte, subjectID int) ([]LandMark, error) {
+	marks := make([]LandMark, len(entries))
+	for i, entry := range entries {
+		mark, err := parseProtoLandmark(entry)
+		if err != nil {
+			return nil, err
+		}
+		mark.ID = i
+		mark.FaceID = subjectID
+		mark.HandID = subjectID
+		marks[i] = mark
+	}
+	return marks, nil
+}
+
+func parseProtoLandmark(message []byte) (LandMark, error) {
+	mark := LandMark{}
+	for len(message) > 0 {
+		num, typ, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return mark, protowire.ParseError(n)
+		}
+		message = message[n:]
+
+		if typ != protowire.Fixed32Type {
+			n = protowire.ConsumeFieldValue(num, typ, message)
+			if n < 0 {
+				return mark, protowire.ParseError(n)
+			}
+			message = message[n:]
+			continue
+		}
+
+		bits, n := protowire.ConsumeFixed32(message)
+		if n < 0 {
+			return mark, protowire.ParseError(n)
+		}
+		message = message[n:]
+		val := float64(math.Float32frombits(bits))
+
+		switch num {
+		case protoFieldX:
+			mark.X = val
+		case protoFieldY:
+			mark.Y = val
+		case protoFieldZ:
+			mark.Z = val
+		case protoFieldVisibility:
+			mark.Visibility = &val
+		case protoFieldPresence:
+			mark.Presence = &val
+		}
+	}
+	return mark, nil
+}
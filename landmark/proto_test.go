@@ -0,0 +1,195 @@
+package landmark
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protoLandmark encodes a mediapipe Landmark message. Visibility and presence
+// are left out when negative.
+func protoLandmark(x, y, z, visibility, presence float32) []byte {
+	b := make([]byte, 0)
+	for _, field := range []struct {
+		num protowire.Number
+		val float32
+	}{
+		{protoFieldX, x},
+		{protoFieldY, y},
+		{protoFieldZ, z},
+		{protoFieldVisibility, visibility},
+		{protoFieldPresence, presence},
+	} {
+		if field.val < 0 {
+			continue
+		}
+		b = protowire.AppendTag(b, field.num, protowire.Fixed32Type)
+		b = protowire.AppendFixed32(b, math.Float32bits(field.val))
+	}
+	return b
+}
+
+// protoList encodes a message holding each entry within the repeated list
+// field.
+func protoList(entries ...[]byte) []byte {
+	b := make([]byte, 0)
+	for _, entry := range entries {
+		b = protowire.AppendTag(b, protoFieldList, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	return b
+}
+
+func delimited(messages ...[]byte) []byte {
+	b := make([]byte, 0)
+	for _, message := range messages {
+		b = protowire.AppendBytes(b, message)
+	}
+	return b
+}
+
+func TestProtoDecoder(t *testing.T) {
+	type want struct {
+		id         int
+		subject    int
+		x          float64
+		visibility bool
+		presence   bool
+	}
+
+	unknownField := protowire.AppendVarint(protowire.AppendTag(nil, 9, protowire.VarintType), 42)
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  [][]want
+	}{
+		{
+			name: "landmark list",
+			input: delimited(protoList(
+				protoLandmark(0.5, 0.25, 0, 0.9, 0.8),
+				protoLandmark(0.75, 0, 0, -1, -1),
+			)),
+			want: [][]want{{
+				{id: 0, x: 0.5, visibility: true, presence: true},
+				{id: 1, x: 0.75},
+			}},
+		},
+		{
+			name: "collection of lists",
+			input: delimited(protoList(
+				protoList(protoLandmark(0.25, 0, 0, -1, -1)),
+				protoList(protoLandmark(0.5, 0, 0, -1, -1), protoLandmark(1, 0, 0, -1, -1)),
+			)),
+			want: [][]want{{
+				{id: 0, subject: 0, x: 0.25},
+				{id: 0, subject: 1, x: 0.5},
+				{id: 1, subject: 1, x: 1},
+			}},
+		},
+		{
+			name: "many frames",
+			input: delimited(
+				protoList(protoLandmark(0.25, 0, 0, -1, -1)),
+				protoList(),
+				protoList(protoLandmark(0.5, 0, 0, -1, -1)),
+			),
+			want: [][]want{{{x: 0.25}}, {}, {{x: 0.5}}},
+		},
+		{
+			name: "unknown fields skipped",
+			input: delimited(append(protoList(
+				append(protoLandmark(0.5, 0, 0, -1, -1), unknownField...),
+			), unknownField...)),
+			want: [][]want{{{x: 0.5}}},
+		},
+		{
+			name:  "empty stream",
+			input: []byte{},
+			want:  [][]want{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewProtoDecoder(bytes.NewReader(tc.input))
+			for f, wantMarks := range tc.want {
+				frame, err := d.Read()
+				if err != nil {
+					t.Fatalf("frame %d: got error %v", f, err)
+				}
+				if len(frame.Landmarks) != len(wantMarks) {
+					t.Fatalf("frame %d: got %d landmarks, want %d", f, len(frame.Landmarks), len(wantMarks))
+				}
+				for i, w := range wantMarks {
+					mark := frame.Landmarks[i]
+					if mark.ID != w.id || mark.FaceID != w.subject || mark.HandID != w.subject || mark.X != w.x {
+						t.Fatalf("frame %d landmark %d: got %v (subject %d), want %+v", f, i, mark, mark.FaceID, w)
+					}
+					if (mark.Visibility != nil) != w.visibility || (mark.Presence != nil) != w.presence {
+						t.Fatalf("frame %d landmark %d: got visibility %v and presence %v, want %+v", f, i, mark.Visibility, mark.Presence, w)
+					}
+				}
+			}
+
+			if _, err := d.Read(); err != io.EOF {
+				t.Fatalf("got %v after the last frame, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestProtoDecoderErrors(t *testing.T) {
+	tooLarge := protowire.AppendVarint(nil, maxProtoMessageSize+1)
+	truncated := delimited(protoList(protoLandmark(0.5, 0, 0, -1, -1)))
+	truncated = truncated[:len(truncated)-2]
+	badLandmark := delimited(protoList([]byte{0x0d, 0x00}))
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{"message too large", tooLarge, "exceeds the limit"},
+		{"truncated message", truncated, "unexpected EOF"},
+		{"truncated landmark", badLandmark, "frame 1"},
+		{"truncated length", []byte{0x80}, "frame 1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewProtoDecoder(bytes.NewReader(tc.input)).Read()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want one containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestProtoDecoderChecksFileSize(t *testing.T) {
+	// A length prefix promising more than the file holds is caught before
+	// anything is allocated for it.
+	path := filepath.Join(t.TempDir(), "frames.pb")
+	input := append(protowire.AppendVarint(nil, 1<<20), 1, 2, 3)
+	if err := ioutil.WriteFile(path, input, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	_, err = NewProtoDecoder(f).Read()
+	if err == nil || !strings.Contains(err.Error(), "runs past the end of the input") {
+		t.Fatalf("got error %v, want one about running past the end of the input", err)
+	}
+}
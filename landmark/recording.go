@@ -0,0 +1,53 @@
+package landmark
+
+import (
+	"strconv"
+
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// PositionCollectionName is the name of the collection that places a
+// recording during playback, such as the trajectory of a landmark within the
+// recording built for it.
+const PositionCollectionName = "Position"
+
+// IDMetadataKey is the metadata key the ID of a landmark within its topology
+// is stored under, on the recording built for the landmark.
+const IDMetadataKey = "landmark-id"
+
+// ID reads the ID within its topology of the landmark a recording was built
+// for. ok is false for any recording that wasn't built for a single
+// landmark, such as a subject's recording or a line drawn out of a landmark.
+func ID(recording format.Recording) (id int, ok bool) {
+	if len(recording.Recordings()) > 0 || Positions(recording) == nil {
+		return 0, false
+	}
+
+	property, ok := recording.Metadata().Mapping()[IDMetadataKey]
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.Atoi(property.String())
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// Positions is every capture within the Position collection of a recording,
+// or nil if the recording has none.
+func Positions(recording format.Recording) []position.Capture {
+	for _, collection := range recording.CaptureCollections() {
+		positions, ok := collection.(position.Collection)
+		if !ok || positions.Name() != PositionCollectionName {
+			continue
+		}
+		captures := make([]position.Capture, positions.Length())
+		for i := range captures {
+			captures[i] = positions.CaptureAt(i).(position.Capture)
+		}
+		return captures
+	}
+	return nil
+}
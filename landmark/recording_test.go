@@ -0,0 +1,44 @@
+package landmark
+
+import (
+	"testing"
+
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/position"
+	"github.com/recolude/rap/format/metadata"
+)
+
+func TestID(t *testing.T) {
+	positions := []format.CaptureCollection{
+		position.NewCollection(PositionCollectionName, []position.Capture{position.NewCapture(0, 1, 2, 3)}),
+	}
+	withID := func(property metadata.Property) metadata.Block {
+		block := metadata.EmptyBlock()
+		block.Mapping()[IDMetadataKey] = property
+		return block
+	}
+	mark := format.NewRecording("0", "NOSE", positions, nil, withID(metadata.NewIntProperty(4)), nil, nil)
+
+	tests := []struct {
+		name      string
+		recording format.Recording
+		want      int
+		wantOK    bool
+	}{
+		{"landmark", mark, 4, true},
+		{"zero", format.NewRecording("0", "", positions, nil, withID(metadata.NewIntProperty(0)), nil, nil), 0, true},
+		{"no ID", format.NewRecording("0", "", positions, nil, metadata.EmptyBlock(), nil, nil), 0, false},
+		{"ID not a number", format.NewRecording("0", "", positions, nil, withID(metadata.NewStringProperty("nose")), nil, nil), 0, false},
+		{"no positions", format.NewRecording("0", "", nil, nil, withID(metadata.NewIntProperty(4)), nil, nil), 0, false},
+		{"subject", format.NewRecording("0", "", positions, []format.Recording{mark}, withID(metadata.NewIntProperty(4)), nil, nil), 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ID(tc.recording)
+			if got != tc.want || ok != tc.wantOK {
+				t.Fatalf("got %d (%v), want %d (%v)", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
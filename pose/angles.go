@@ -0,0 +1,123 @@
+package pose
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/topology"
+)
+
+// flatten removes the part of v running along the normal, leaving only the
+// part lying within the plane.
+func flatten(v, normal vector.Vector3) vector.Vector3 {
+	return v.Sub(normal.MultByConstant(v.Dot(normal) / normal.Dot(normal)))
+}
+
+// measureAngle measures the joint angle, in degrees, between the landmarks
+// of a single frame, indexed by landmark. ok is false if any of the angle's
+// landmarks are missing, or either segment has no length once flattened into
+// the angle's plane.
+func measureAngle(angle topology.Angle, points []vector.Vector3, found []bool) (degrees float64, ok bool) {
+	for _, i := range angle.Landmarks() {
+		if i >= len(found) || !found[i] {
+			return 0, false
+		}
+	}
+
+	at := func(point topology.Point) vector.Vector3 {
+		sum := vector.Vector3Zero()
+		for _, i := range point {
+			sum = sum.Add(points[i])
+		}
+		return sum.DivByConstant(float64(len(point)))
+	}
+
+	from := at(angle.From[1]).Sub(at(angle.From[0]))
+	to := at(angle.To[1]).Sub(at(angle.To[0]))
+
+	switch len(angle.Plane) {
+	case 2:
+		normal := at(angle.Plane[1]).Sub(at(angle.Plane[0]))
+		if normal.Length() == 0 {
+			return 0, false
+		}
+		from, to = flatten(from, normal), flatten(to, normal)
+
+	case 3:
+		origin := at(angle.Plane[0])
+		normal := at(angle.Plane[1]).Sub(origin).Cross(at(angle.Plane[2]).Sub(origin))
+		if normal.Length() == 0 {
+			return 0, false
+		}
+		from, to = flatten(from, normal), flatten(to, normal)
+	}
+
+	lengths := from.Length() * to.Length()
+	if lengths == 0 {
+		return 0, false
+	}
+	cos := math.Max(-1, math.Min(1, from.Dot(to)/lengths))
+	return math.Acos(cos) * 180 / math.Pi, true
+}
+
+// framePoints indexes the landmarks of a frame by ID, for every landmark the
+// model defines.
+func framePoints(model *topology.Topology, frame []landmark.LandMark) (points []vector.Vector3, found []bool) {
+	points = make([]vector.Vector3, len(model.Landmarks))
+	found = make([]bool, len(model.Landmarks))
+	for _, mark := range frame {
+		if mark.ID >= 0 && mark.ID < len(points) {
+			points[mark.ID] = vector.NewVector3(mark.X, mark.Y, mark.Z)
+			found[mark.ID] = true
+		}
+	}
+	return points, found
+}
+
+// RangeOfMotion is how far a joint angle moved over the clip.
+type RangeOfMotion struct {
+	// Name is the name of the joint angle.
+	Name string
+
+	// Min and Max are the smallest and largest the angle was, in degrees.
+	Min float64
+	Max float64
+
+	// Frames is how many frames the angle was measured in.
+	Frames int
+}
+
+// Range is how many degrees the joint angle moved through.
+func (r RangeOfMotion) Range() float64 {
+	return r.Max - r.Min
+}
+
+func (r RangeOfMotion) String() string {
+	return fmt.Sprintf("%s: %.1f° to %.1f° (%.1f°) over %d frames", r.Name, r.Min, r.Max, r.Range(), r.Frames)
+}
+
+// rangesOfMotion finds the range of motion of every angle measured at least
+// once.
+func (rd *runningData) rangesOfMotion() []RangeOfMotion {
+	ranges := make([]RangeOfMotion, 0, len(rd.angles))
+	for i, captures := range rd.angles {
+		if len(captures) == 0 {
+			continue
+		}
+
+		rom := RangeOfMotion{
+			Name:   rd.model.Angles[i].Name,
+			Min:    math.Inf(1),
+			Max:    math.Inf(-1),
+			Frames: len(captures),
+		}
+		for _, capture := range captures {
+			rom.Min = math.Min(rom.Min, capture.Value())
+			rom.Max = math.Max(rom.Max, capture.Value())
+		}
+		ranges = append(ranges, rom)
+	}
+	return ranges
+}
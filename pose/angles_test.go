@@ -0,0 +1,141 @@
+package pose
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/topology"
+	"github.com/recolude/rap/format/collection/float"
+)
+
+func TestMeasureAngle(t *testing.T) {
+	// A joint at the origin, between landmarks 0 and 2, with landmark 3
+	// offering a plane.
+	points := func(end vector.Vector3) []vector.Vector3 {
+		return []vector.Vector3{
+			vector.NewVector3(0, 1, 0),
+			vector.Vector3Zero(),
+			end,
+			vector.NewVector3(0, 0, 1),
+		}
+	}
+	all := []bool{true, true, true, true}
+	bend := topology.Angle{Name: "Bend", From: [2]topology.Point{{0}, {1}}, To: [2]topology.Point{{1}, {2}}}
+
+	withPlane := func(plane ...topology.Point) topology.Angle {
+		angle := bend
+		angle.Plane = plane
+		return angle
+	}
+
+	tests := []struct {
+		name   string
+		angle  topology.Angle
+		points []vector.Vector3
+		found  []bool
+		want   float64
+		wantOK bool
+	}{
+		{"straight", bend, points(vector.NewVector3(0, -1, 0)), all, 0, true},
+		{"right angle", bend, points(vector.NewVector3(1, 0, 0)), all, 90, true},
+		{"folded back", bend, points(vector.NewVector3(0, 1, 0)), all, 180, true},
+		{"landmark missing", bend, points(vector.NewVector3(1, 0, 0)), []bool{true, true, false, true}, 0, false},
+		{"landmark past the frame", bend, points(vector.NewVector3(1, 0, 0))[:2], []bool{true, true}, 0, false},
+		{"no length", bend, points(vector.Vector3Zero()), all, 0, false},
+		{
+			name:   "averaged point",
+			angle:  topology.Angle{Name: "Bend", From: [2]topology.Point{{0}, {1}}, To: [2]topology.Point{{1}, {2, 3}}},
+			points: points(vector.NewVector3(0, -1, -1)),
+			found:  all,
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "flattened along a normal",
+			angle:  withPlane(topology.Point{1}, topology.Point{3}),
+			points: points(vector.NewVector3(1, -1, 5)),
+			found:  all,
+			want:   45,
+			wantOK: true,
+		},
+		{
+			name:   "flattened into a plane through three points",
+			angle:  withPlane(topology.Point{1}, topology.Point{0}, topology.Point{3}),
+			points: points(vector.NewVector3(1, -1, 0)),
+			found:  all,
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "segment along the normal",
+			angle:  withPlane(topology.Point{1}, topology.Point{3}),
+			points: points(vector.NewVector3(0, 0, 1)),
+			found:  all,
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := measureAngle(tc.angle, tc.points, tc.found)
+			if ok != tc.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, tc.wantOK)
+			}
+			if math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("got %g degrees, want %g", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRangesOfMotion(t *testing.T) {
+	knee := func(degrees float64) []landmark.LandMark {
+		radians := degrees * math.Pi / 180
+		return []landmark.LandMark{
+			{ID: 23, Y: 0},
+			{ID: 25, Y: 0.5},
+			{ID: 27, Y: 0.5 + 0.5*math.Cos(radians), Z: 0.5 * math.Sin(radians)},
+		}
+	}
+
+	converter := NewConverter(30)
+	converter.JointAngles = true
+	builder := converter.NewBuilder()
+	for i, degrees := range []float64{10, 90, 45} {
+		if err := builder.Add(float64(i)/30, knee(degrees)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ranges := builder.RangesOfMotion()
+	if len(ranges) != 1 || ranges[0].Name != "Left Knee Flexion" || ranges[0].Frames != 3 {
+		t.Fatalf("got ranges %v, want Left Knee Flexion alone over 3 frames", ranges)
+	}
+	if math.Abs(ranges[0].Min-10) > 1e-9 || math.Abs(ranges[0].Max-90) > 1e-9 || math.Abs(ranges[0].Range()-80) > 1e-9 {
+		t.Fatalf("got range %v, want 10 to 90 degrees", ranges[0])
+	}
+
+	angles := make([]float64, 0)
+	for _, collection := range builder.Recording().CaptureCollections() {
+		if collection.Name() == "Left Knee Flexion" {
+			for _, capture := range collection.Captures() {
+				angles = append(angles, capture.(float.Capture).Value())
+			}
+		}
+	}
+	if len(angles) != 3 || math.Abs(angles[2]-45) > 1e-9 {
+		t.Fatalf("got angles %v, want 3 ending at 45 degrees", angles)
+	}
+}
+
+func TestRangesOfMotionWithoutJointAngles(t *testing.T) {
+	builder := NewConverter(30).NewBuilder()
+	if err := builder.Add(0, []landmark.LandMark{{ID: 23}, {ID: 25, Y: 1}, {ID: 27, Y: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if ranges := builder.RangesOfMotion(); len(ranges) != 0 {
+		t.Fatalf("got ranges %v, want none", ranges)
+	}
+}
@@ -0,0 +1,405 @@
+package pose
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// maxBVHFrames bounds how many frames are resampled for a BVH file, as a clip
+// whose detections are mostly close together but span a long stretch of time
+// would otherwise be resampled into an unbounded number of frames.
+const maxBVHFrames = 1 << 20
+
+// bvhScale converts mediapipe's world landmarks, which are in meters, to the
+// centimeters BVH files are conventionally authored in.
+const bvhScale = 100
+
+var (
+	bvhUp      = vector.NewVector3(0, 1, 0)
+	bvhDown    = vector.NewVector3(0, -1, 0)
+	bvhLeft    = vector.NewVector3(1, 0, 0)
+	bvhRight   = vector.NewVector3(-1, 0, 0)
+	bvhForward = vector.NewVector3(0, 0, 1)
+)
+
+// bvhJoint is a single joint of the skeleton solved out of the pose
+// landmarks.
+type bvhJoint struct {
+	name   string
+	parent int
+
+	// landmarks are averaged to find where the joint sits.
+	landmarks []int
+
+	// offset is the direction of the joint from its parent in the rest pose.
+	offset vector.Vector3
+
+	// aim are the landmarks, averaged, the joint's bone points towards.
+	aim []int
+
+	// bone is the direction the joint's bone points in the rest pose.
+	bone vector.Vector3
+
+	// side, if set, is a pair of landmarks running from the subject's left to
+	// right, used to resolve the twist of joints with several children.
+	side []int
+
+	// endSite is whether the bone ends at its aim rather than at a child
+	// joint.
+	endSite bool
+}
+
+// bvhSkeleton is the hierarchy written to BVH files, rooted at the hips. The
+// rest pose is a T-pose facing +Z. Limb bones follow the edges of mediapipe-pose.
+var bvhSkeleton = []bvhJoint{
+	{name: "Hips", parent: -1, landmarks: []int{23, 24}, aim: []int{11, 12}, bone: bvhUp, side: []int{23, 24}},
+	{name: "Chest", parent: 0, landmarks: []int{11, 12}, offset: bvhUp, aim: []int{7, 8}, bone: bvhUp, side: []int{11, 12}},
+	{name: "Head", parent: 1, landmarks: []int{7, 8}, offset: bvhUp, aim: []int{0}, bone: bvhForward, endSite: true},
+
+	{name: "LeftArm", parent: 1, landmarks: []int{11}, offset: bvhLeft, aim: []int{13}, bone: bvhLeft},
+	{name: "LeftForeArm", parent: 3, landmarks: []int{13}, offset: bvhLeft, aim: []int{15}, bone: bvhLeft},
+	{name: "LeftHand", parent: 4, landmarks: []int{15}, offset: bvhLeft, aim: []int{19}, bone: bvhLeft, endSite: true},
+
+	{name: "RightArm", parent: 1, landmarks: []int{12}, offset: bvhRight, aim: []int{14}, bone: bvhRight},
+	{name: "RightForeArm", parent: 6, landmarks: []int{14}, offset: bvhRight, aim: []int{16}, bone: bvhRight},
+	{name: "RightHand", parent: 7, landmarks: []int{16}, offset: bvhRight, aim: []int{20}, bone: bvhRight, endSite: true},
+
+	{name: "LeftUpLeg", parent: 0, landmarks: []int{23}, offset: bvhLeft, aim: []int{25}, bone: bvhDown},
+	{name: "LeftLeg", parent: 9, landmarks: []int{25}, offset: bvhDown, aim: []int{27}, bone: bvhDown},
+	{name: "LeftFoot", parent: 10, landmarks: []int{27}, offset: bvhDown, aim: []int{31}, bone: bvhForward, endSite: true},
+
+	{name: "RightUpLeg", parent: 0, landmarks: []int{24}, offset: bvhRight, aim: []int{26}, bone: bvhDown},
+	{name: "RightLeg", parent: 12, landmarks: []int{26}, offset: bvhDown, aim: []int{28}, bone: bvhDown},
+	{name: "RightFoot", parent: 13, landmarks: []int{28}, offset: bvhDown, aim: []int{32}, bone: bvhForward, endSite: true},
+}
+
+// matrix3 is a 3x3 rotation matrix, indexed by row then column.
+type matrix3 [3][3]float64
+
+func identity() matrix3 {
+	return matrix3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+}
+
+// columns builds the matrix whose columns are the provided vectors.
+func columns(a, b, c vector.Vector3) matrix3 {
+	return matrix3{
+		{a.X(), b.X(), c.X()},
+		{a.Y(), b.Y(), c.Y()},
+		{a.Z(), b.Z(), c.Z()},
+	}
+}
+
+func (m matrix3) mul(o matrix3) matrix3 {
+	var out matrix3
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			out[r][c] = m[r][0]*o[0][c] + m[r][1]*o[1][c] + m[r][2]*o[2][c]
+		}
+	}
+	return out
+}
+
+func (m matrix3) transposed() matrix3 {
+	var out matrix3
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			out[r][c] = m[c][r]
+		}
+	}
+	return out
+}
+
+func (m matrix3) apply(v vector.Vector3) vector.Vector3 {
+	return vector.NewVector3(
+		m[0][0]*v.X()+m[0][1]*v.Y()+m[0][2]*v.Z(),
+		m[1][0]*v.X()+m[1][1]*v.Y()+m[1][2]*v.Z(),
+		m[2][0]*v.X()+m[2][1]*v.Y()+m[2][2]*v.Z(),
+	)
+}
+
+// eulerZXY decomposes the rotation into the angles, in degrees, about Z, X
+// and Y that compose it in that order, matching the channel order written
+// to BVH files.
+func (m matrix3) eulerZXY() (z, x, y float64) {
+	sx := math.Max(-1, math.Min(1, m[2][1]))
+	x = math.Asin(sx)
+	if math.Abs(sx) < 1-1e-9 {
+		y = math.Atan2(-m[2][0], m[2][2])
+		z = math.Atan2(-m[0][1], m[1][1])
+	} else {
+		z = math.Atan2(m[1][0], m[0][0])
+	}
+	return z * 180 / math.Pi, x * 180 / math.Pi, y * 180 / math.Pi
+}
+
+// rotationBetween is the shortest rotation taking the unit vector from onto
+// the unit vector to.
+func rotationBetween(from, to vector.Vector3) matrix3 {
+	c := from.Dot(to)
+	if c < -1+1e-9 {
+		// Turning halfway around any axis perpendicular to the vectors.
+		axis := from.Perpendicular().Normalized()
+		a := [3]float64{axis.X(), axis.Y(), axis.Z()}
+		m := identity()
+		for r := 0; r < 3; r++ {
+			for col := 0; col < 3; col++ {
+				m[r][col] = 2*a[r]*a[col] - m[r][col]
+			}
+		}
+		return m
+	}
+
+	v := from.Cross(to)
+	skew := matrix3{
+		{0, -v.Z(), v.Y()},
+		{v.Z(), 0, -v.X()},
+		{-v.Y(), v.X(), 0},
+	}
+	skewSquared := skew.mul(skew)
+
+	m := identity()
+	for r := 0; r < 3; r++ {
+		for col := 0; col < 3; col++ {
+			m[r][col] += skew[r][col] + skewSquared[r][col]/(1+c)
+		}
+	}
+	return m
+}
+
+// basis is the orientation whose Y axis points up and whose X axis points as
+// close to side as possible.
+func basis(up, side vector.Vector3) matrix3 {
+	u := up.Normalized()
+	f := side.Cross(u).Normalized()
+	s := u.Cross(f)
+	return columns(s, u, f)
+}
+
+// usable is whether the vector is long enough to take a direction from.
+func usable(v vector.Vector3) bool {
+	return v.Length() > 1e-9
+}
+
+// sampler finds where each landmark was at any time by interpolating between
+// its captures.
+type sampler struct {
+	captures [][]position.Capture
+}
+
+func (s sampler) at(landmarkIndex int, t float64) vector.Vector3 {
+	captures := s.captures[landmarkIndex]
+	i := sort.Search(len(captures), func(i int) bool {
+		return captures[i].Time() >= t
+	})
+	if i == 0 {
+		return bvhSpace(captures[0].Position())
+	}
+	if i == len(captures) {
+		return bvhSpace(captures[len(captures)-1].Position())
+	}
+
+	before, after := captures[i-1], captures[i]
+	span := after.Time() - before.Time()
+	if span <= 0 {
+		return bvhSpace(after.Position())
+	}
+	amount := (t - before.Time()) / span
+	start := before.Position()
+	return bvhSpace(start.Add(after.Position().Sub(start).MultByConstant(amount)))
+}
+
+func (s sampler) average(landmarks []int, t float64) vector.Vector3 {
+	sum := vector.Vector3Zero()
+	for _, landmarkIndex := range landmarks {
+		sum = sum.Add(s.at(landmarkIndex, t))
+	}
+	return sum.DivByConstant(float64(len(landmarks)))
+}
+
+// bvhSpace moves a point from mediapipe's coordinates (y down, z away from
+// the camera) into the right handed, y up space BVH files are read in.
+func bvhSpace(v vector.Vector3) vector.Vector3 {
+	return vector.NewVector3(v.X(), -v.Y(), -v.Z()).MultByConstant(bvhScale)
+}
+
+// bvhFrameTime is the typical time between the frames the pose was detected
+// in.
+func bvhFrameTime(seen []float64, frameRate float64) float64 {
+	steps := make([]float64, 0, len(seen))
+	for i := 1; i < len(seen); i++ {
+		if step := seen[i] - seen[i-1]; step > 0 {
+			steps = append(steps, step)
+		}
+	}
+	if len(steps) == 0 {
+		return 1 / frameRate
+	}
+	sort.Float64s(steps)
+	return steps[len(steps)/2]
+}
+
+// bvhPose is where every joint sits and what it aims at within a single
+// frame.
+type bvhPose struct {
+	joints []vector.Vector3
+	aims   []vector.Vector3
+	sides  []vector.Vector3
+}
+
+func (s sampler) pose(t float64) bvhPose {
+	p := bvhPose{
+		joints: make([]vector.Vector3, len(bvhSkeleton)),
+		aims:   make([]vector.Vector3, len(bvhSkeleton)),
+		sides:  make([]vector.Vector3, len(bvhSkeleton)),
+	}
+	for i, joint := range bvhSkeleton {
+		p.joints[i] = s.average(joint.landmarks, t)
+		p.aims[i] = s.average(joint.aim, t)
+		if joint.side != nil {
+			p.sides[i] = s.at(joint.side[0], t).Sub(s.at(joint.side[1], t))
+		}
+	}
+	return p
+}
+
+// localRotations solves the rotation of every joint relative to its parent
+// that best takes the rest pose to the pose.
+func (p bvhPose) localRotations() []matrix3 {
+	global := make([]matrix3, len(bvhSkeleton))
+	local := make([]matrix3, len(bvhSkeleton))
+	for i, joint := range bvhSkeleton {
+		parent := identity()
+		if joint.parent >= 0 {
+			parent = global[joint.parent]
+		}
+
+		bone := p.aims[i].Sub(p.joints[i])
+		switch {
+		case !usable(bone):
+			global[i] = parent
+
+		case joint.side != nil && usable(p.sides[i]):
+			global[i] = basis(bone, p.sides[i]).mul(basis(joint.bone, bvhLeft).transposed())
+
+		default:
+			// Solving within the parent's space keeps the bone from
+			// twisting any more than it has to.
+			global[i] = parent.mul(rotationBetween(joint.bone, parent.transposed().apply(bone.Normalized())))
+		}
+		local[i] = parent.transposed().mul(global[i])
+	}
+	return local
+}
+
+func (rd *runningData) writeBVH(out io.Writer) error {
+	if rd.model.Name != MediaPipe.Name {
+		return fmt.Errorf("BVH export requires the %s model, the pose uses %s", MediaPipe.Name, rd.model.Name)
+	}
+	if len(rd.seen) == 0 {
+		return errors.New("no pose was detected")
+	}
+	for _, joint := range bvhSkeleton {
+		for _, landmarks := range [][]int{joint.landmarks, joint.aim} {
+			for _, landmarkIndex := range landmarks {
+				if landmarkIndex >= len(rd.captures) || len(rd.captures[landmarkIndex]) == 0 {
+					return fmt.Errorf("landmark %d required by %s was never detected", landmarkIndex, joint.name)
+				}
+			}
+		}
+	}
+
+	s := sampler{captures: rd.captures}
+	frameTime := bvhFrameTime(rd.seen, rd.frameRate)
+	start, end := rd.seen[0], rd.seen[len(rd.seen)-1]
+	frames := math.Round((end-start)/frameTime) + 1
+	if frames > maxBVHFrames {
+		return fmt.Errorf("resampling %gs of pose every %gs would take %g frames, more than the %d a BVH file is limited to", end-start, frameTime, frames, maxBVHFrames)
+	}
+	frameCount := int(frames)
+
+	poses := make([]bvhPose, frameCount)
+	offsets := make([]float64, len(bvhSkeleton))
+	ends := make([]float64, len(bvhSkeleton))
+	for f := range poses {
+		poses[f] = s.pose(start + (float64(f) * frameTime))
+		for i, joint := range bvhSkeleton {
+			if joint.parent >= 0 {
+				offsets[i] += poses[f].joints[i].Distance(poses[f].joints[joint.parent])
+			}
+			ends[i] += poses[f].aims[i].Distance(poses[f].joints[i])
+		}
+	}
+
+	w := bufio.NewWriter(out)
+	fmt.Fprintln(w, "HIERARCHY")
+
+	var writeJoint func(index, depth int)
+	writeJoint = func(index, depth int) {
+		joint := bvhSkeleton[index]
+		indent := strings.Repeat("\t", depth)
+
+		offset := joint.offset.MultByConstant(offsets[index] / float64(frameCount))
+		if joint.parent < 0 {
+			fmt.Fprintf(w, "%sROOT %s\n", indent, joint.name)
+		} else {
+			fmt.Fprintf(w, "%sJOINT %s\n", indent, joint.name)
+		}
+		fmt.Fprintf(w, "%s{\n", indent)
+		fmt.Fprintf(w, "%s\tOFFSET %.6f %.6f %.6f\n", indent, offset.X(), offset.Y(), offset.Z())
+		if joint.parent < 0 {
+			fmt.Fprintf(w, "%s\tCHANNELS 6 Xposition Yposition Zposition Zrotation Xrotation Yrotation\n", indent)
+		} else {
+			fmt.Fprintf(w, "%s\tCHANNELS 3 Zrotation Xrotation Yrotation\n", indent)
+		}
+
+		for child, childJoint := range bvhSkeleton {
+			if childJoint.parent == index {
+				writeJoint(child, depth+1)
+			}
+		}
+
+		if joint.endSite {
+			site := joint.bone.MultByConstant(ends[index] / float64(frameCount))
+			fmt.Fprintf(w, "%s\tEnd Site\n", indent)
+			fmt.Fprintf(w, "%s\t{\n", indent)
+			fmt.Fprintf(w, "%s\t\tOFFSET %.6f %.6f %.6f\n", indent, site.X(), site.Y(), site.Z())
+			fmt.Fprintf(w, "%s\t}\n", indent)
+		}
+		fmt.Fprintf(w, "%s}\n", indent)
+	}
+	writeJoint(0, 0)
+
+	fmt.Fprintln(w, "MOTION")
+	fmt.Fprintf(w, "Frames: %d\n", frameCount)
+	fmt.Fprintf(w, "Frame Time: %.6f\n", frameTime)
+	for _, pose := range poses {
+		root := pose.joints[0]
+		fmt.Fprintf(w, "%.6f %.6f %.6f", root.X(), root.Y(), root.Z())
+
+		// Channels are written in the same depth first order the hierarchy
+		// was.
+		rotations := pose.localRotations()
+		var writeRotations func(index int)
+		writeRotations = func(index int) {
+			z, x, y := rotations[index].eulerZXY()
+			fmt.Fprintf(w, " %.6f %.6f %.6f", z, x, y)
+			for child, childJoint := range bvhSkeleton {
+				if childJoint.parent == index {
+					writeRotations(child)
+				}
+			}
+		}
+		writeRotations(0)
+		fmt.Fprintln(w)
+	}
+
+	return w.Flush()
+}
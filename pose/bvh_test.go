@@ -0,0 +1,221 @@
+package pose
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/landmark"
+)
+
+// tPose is a subject standing in the BVH rest pose, in meters, written in
+// BVH's space (y up, facing +z) and keyed by mediapipe landmark.
+func tPose() map[int]vector.Vector3 {
+	return map[int]vector.Vector3{
+		0:  vector.NewVector3(0, 1.6, 0.1),
+		7:  vector.NewVector3(0.05, 1.6, 0),
+		8:  vector.NewVector3(-0.05, 1.6, 0),
+		11: vector.NewVector3(0.2, 1.4, 0),
+		12: vector.NewVector3(-0.2, 1.4, 0),
+		13: vector.NewVector3(0.5, 1.4, 0),
+		14: vector.NewVector3(-0.5, 1.4, 0),
+		15: vector.NewVector3(0.8, 1.4, 0),
+		16: vector.NewVector3(-0.8, 1.4, 0),
+		19: vector.NewVector3(0.9, 1.4, 0),
+		20: vector.NewVector3(-0.9, 1.4, 0),
+		23: vector.NewVector3(0.1, 0.9, 0),
+		24: vector.NewVector3(-0.1, 0.9, 0),
+		25: vector.NewVector3(0.1, 0.5, 0),
+		26: vector.NewVector3(-0.1, 0.5, 0),
+		27: vector.NewVector3(0.1, 0.1, 0),
+		28: vector.NewVector3(-0.1, 0.1, 0),
+		31: vector.NewVector3(0.1, 0.1, 0.1),
+		32: vector.NewVector3(-0.1, 0.1, 0.1),
+	}
+}
+
+// worldLandmarks moves the points into mediapipe's world coordinates.
+func worldLandmarks(points map[int]vector.Vector3) []landmark.LandMark {
+	marks := make([]landmark.LandMark, 0, len(points))
+	for id := 0; id < len(MediaPipe.Landmarks); id++ {
+		if p, ok := points[id]; ok {
+			marks = append(marks, landmark.LandMark{ID: id, X: p.X(), Y: -p.Y(), Z: -p.Z()})
+		}
+	}
+	return marks
+}
+
+// bvhMotion parses the channels of every frame within the motion section.
+func bvhMotion(t *testing.T, bvh string) [][]float64 {
+	t.Helper()
+	sections := strings.SplitN(bvh, "MOTION\n", 2)
+	if len(sections) != 2 {
+		t.Fatalf("no motion section within %q", bvh)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sections[1]), "\n")
+	frames := make([][]float64, 0, len(lines)-2)
+	for _, line := range lines[2:] {
+		fields := strings.Fields(line)
+		channels := make([]float64, len(fields))
+		for i, field := range fields {
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			channels[i] = v
+		}
+		frames = append(frames, channels)
+	}
+	return frames
+}
+
+func TestWriteBVH(t *testing.T) {
+	raised := tPose()
+	raised[13] = vector.NewVector3(0.2, 1.7, 0)
+	raised[15] = vector.NewVector3(0.2, 2, 0)
+	raised[19] = vector.NewVector3(0.2, 2.1, 0)
+
+	builder := NewConverter(10).NewBuilder()
+	for i, points := range []map[int]vector.Vector3{tPose(), tPose(), raised} {
+		if err := builder.Add(float64(i)*0.1, worldLandmarks(points)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := bytes.Buffer{}
+	if err := builder.WriteBVH(&out); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	bvh := out.String()
+
+	for _, want := range []string{
+		"ROOT Hips\n",
+		"\t\tJOINT LeftArm\n",
+		"\t\t\tJOINT LeftForeArm\n\t\t\t{\n\t\t\t\tOFFSET 30.000000 0.000000 0.000000\n",
+		"Frames: 3\nFrame Time: 0.100000\n",
+	} {
+		if !strings.Contains(bvh, want) {
+			t.Fatalf("got BVH without %q:\n%s", want, bvh)
+		}
+	}
+
+	frames := bvhMotion(t, bvh)
+	if len(frames) != 3 {
+		t.Fatalf("got %d frames of motion, want 3", len(frames))
+	}
+
+	// LeftArm, the fourth joint, turns 90 degrees about Z to point up.
+	leftArm := 3 + (3 * 3)
+	tests := []struct {
+		name  string
+		frame int
+		want  map[int]float64
+	}{
+		{"rest pose", 0, map[int]float64{0: 0, 1: 90, 2: 0, leftArm: 0, leftArm + 1: 0, leftArm + 2: 0}},
+		{"arm raised", 2, map[int]float64{leftArm: 90, leftArm + 1: 0, leftArm + 2: 0, leftArm + 3: 0}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			channels := frames[tc.frame]
+			if len(channels) != 3+(3*len(bvhSkeleton)) {
+				t.Fatalf("got %d channels, want %d", len(channels), 3+(3*len(bvhSkeleton)))
+			}
+			for i, want := range tc.want {
+				if math.Abs(channels[i]-want) > 1e-3 {
+					t.Fatalf("got channel %d of %g, want %g within %v", i, channels[i], want, channels)
+				}
+			}
+		})
+	}
+
+	for i, channel := range frames[0][3:] {
+		if math.Abs(channel) > 1e-3 {
+			t.Fatalf("got rotation channel %d of %g in the rest pose", i, channel)
+		}
+	}
+}
+
+func TestWriteBVHErrors(t *testing.T) {
+	missingFoot := tPose()
+	delete(missingFoot, 31)
+
+	tests := []struct {
+		name      string
+		converter Converter
+		times     []float64
+		points    map[int]vector.Vector3
+		wantErr   string
+	}{
+		{
+			name:      "other model",
+			converter: Converter{FrameRate: 30, Topology: Body25},
+			times:     []float64{0},
+			points:    map[int]vector.Vector3{0: vector.Vector3Zero()},
+			wantErr:   "requires the mediapipe-pose model",
+		},
+		{
+			name:      "nothing detected",
+			converter: NewConverter(30),
+			wantErr:   "no pose was detected",
+		},
+		{
+			name:      "landmark never detected",
+			converter: NewConverter(30),
+			times:     []float64{0},
+			points:    missingFoot,
+			wantErr:   "landmark 31 required by LeftFoot",
+		},
+		{
+			name:      "too many frames",
+			converter: NewConverter(30),
+			times:     []float64{0, 0.001, 0.002, 0.003, 10000},
+			points:    tPose(),
+			wantErr:   "more than the 1048576 a BVH file is limited to",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := tc.converter.NewBuilder()
+			for _, curTime := range tc.times {
+				if err := builder.Add(curTime, worldLandmarks(tc.points)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := builder.WriteBVH(&bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestEulerZXY(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation matrix3
+		want     [3]float64
+	}{
+		{"identity", identity(), [3]float64{0, 0, 0}},
+		{"about z", rotationBetween(bvhLeft, bvhUp), [3]float64{90, 0, 0}},
+		{"about x", rotationBetween(bvhUp, bvhForward), [3]float64{0, 90, 0}},
+		{"about y", rotationBetween(bvhForward, bvhLeft), [3]float64{0, 0, 90}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			z, x, y := tc.rotation.eulerZXY()
+			got := [3]float64{z, x, y}
+			for i := range got {
+				if math.Abs(got[i]-tc.want[i]) > 1e-9 {
+					t.Fatalf("got z, x, y of %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -1,183 +1,151 @@
-package main
+// Package pose converts mediapipe pose landmarks into recolude recordings.
+package pose
 
 import (
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"os"
+	"io"
 	"strconv"
 
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/topology"
+	"github.com/recolude/pose-recording/tracking"
 	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/event"
+	"github.com/recolude/rap/format/collection/float"
 	"github.com/recolude/rap/format/collection/position"
-	"github.com/recolude/rap/format/encoding"
-	positionEncoder "github.com/recolude/rap/format/encoding/position"
-	rapio "github.com/recolude/rap/format/io"
 	"github.com/recolude/rap/format/metadata"
 )
 
-var landmarkNames = []string{
-	"NOSE",
-	"LEFT EYE_INNER",
-	"LEFT EYE",
-	"LEFT EYE OUTER",
-	"RIGHT EYE INNER",
-	"RIGHT EYE",
-	"RIGHT EYE OUTER",
-	"LEFT EAR",
-	"RIGHT EAR",
-	"MOUTH LEFT",
-	"MOUTH RIGHT",
-
-	"LEFT SHOULDER",
-	"RIGHT SHOULDER",
-	"LEFT ELBOW",
-	"RIGHT ELBOW",
-	"LEFT WRIST",
-	"RIGHT WRIST",
-	"LEFT PINKY",
-	"RIGHT PINKY",
-	"LEFT INDEX",
-	"RIGHT INDEX",
-	"LEFT THUMB",
-	"RIGHT THUMB",
-	"LEFT HIP",
-	"RIGHT HIP",
-	"LEFT KNEE",
-	"RIGHT KNEE",
-	"LEFT ANKLE",
-	"RIGHT ANKLE",
-	"LEFT HEEL",
-	"RIGHT HEEL",
-	"LEFT FOOT INDEX",
-	"RIGHT FOOT INDEX",
-}
-
-var landmarkColors = []string{
-	"#FF0000",
-	"#FFA500",
-	"#FFA500",
-	"#FFA500",
-	"#00FFFF",
-	"#00FFFF",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-	"#FFA500",
-	"#00FFFF",
-}
-
-type Vector2Int struct {
-	X int
-	Y int
-}
-
-func NewVector2Int(x, y int) Vector2Int {
-	return Vector2Int{X: x, Y: y}
-}
-
-var landmarkEdges = []Vector2Int{
-	NewVector2Int(15, 21),
-	NewVector2Int(16, 20),
-	NewVector2Int(18, 20),
-	NewVector2Int(3, 7),
-	NewVector2Int(14, 16),
-	NewVector2Int(23, 25),
-	NewVector2Int(28, 30),
-	NewVector2Int(11, 23),
-	NewVector2Int(27, 31),
-	NewVector2Int(6, 8),
-	NewVector2Int(15, 17),
-	NewVector2Int(24, 26),
-	NewVector2Int(16, 22),
-	NewVector2Int(4, 5),
-	NewVector2Int(5, 6),
-	NewVector2Int(29, 31),
-	NewVector2Int(12, 24),
-	NewVector2Int(23, 24),
-	NewVector2Int(0, 1),
-	NewVector2Int(9, 10),
-	NewVector2Int(1, 2),
-	NewVector2Int(0, 4),
-	NewVector2Int(11, 13),
-	NewVector2Int(30, 32),
-	NewVector2Int(28, 32),
-	NewVector2Int(15, 19),
-	NewVector2Int(16, 18),
-	NewVector2Int(25, 27),
-	NewVector2Int(26, 28),
-	NewVector2Int(12, 14),
-	NewVector2Int(17, 19),
-	NewVector2Int(2, 3),
-	NewVector2Int(11, 12),
-	NewVector2Int(27, 29),
-	NewVector2Int(13, 15),
+// MediaPipe is the 33 landmark model reported by mediapipe pose.
+var MediaPipe = topology.MustBuiltin("mediapipe-pose")
+
+// Body25 is OpenPose's default 25 keypoint body model.
+var Body25 = topology.MustBuiltin("openpose-body25")
+
+// COCO18 is OpenPose's 18 keypoint COCO body model.
+var COCO18 = topology.MustBuiltin("openpose-coco18")
+
+// Models are the built in models, by the short names the command line knows
+// them by.
+var Models = map[string]*topology.Topology{
+	"mediapipe": MediaPipe,
+	"body25":    Body25,
+	"coco18":    COCO18,
 }
 
 type runningData struct {
+	// captures are kept in the detector's coordinates until the entire clip
+	// has been seen and the pose can be placed.
 	captures [][]position.Capture
+
+	// visibility and presence are the likelihoods reported alongside each
+	// landmark, if the detector reports them.
+	visibility [][]float.Capture
+	presence   [][]float.Capture
+
+	// seen is every time the pose was detected.
+	seen []float64
+
+	// smoothing is applied to the landmarks before they're captured, if set.
+	// It's reset whenever the pose reappears after going undetected for
+	// longer than maxGap.
+	smoothing *filter.Stage
+	maxGap    float64
+
+	// frameRate times frames resampled for export.
+	frameRate float64
+
+	// aabb, if set, encompasses every landmark seen so far and is used to
+	// center the pose once the entire clip has been seen.
+	aabb *landmark.AABB
+
+	// idPrefix is prepended to the ID of every landmark's recording.
+	idPrefix string
+
+	// model names and styles the landmarks, and defines the joint angles
+	// measured between them.
+	model *topology.Topology
+
+	// angles are the degrees of each of the model's joint angles every frame
+	// they could be measured, indexed the same as the model's angles. Nil
+	// unless joint angles are being recorded.
+	angles [][]float.Capture
+
+	// repCounters find repetitions within the signals they follow, which
+	// are captured every frame they could be measured in repSignals, and
+	// recorded within the collections named by repNames.
+	repCounters []RepCounter
+	repSignals  [][]float.Capture
+	repNames    []string
+}
+
+// placed moves every capture, which are kept in the detector's coordinates,
+// into the recording's space.
+func (rd *runningData) placed() [][]position.Capture {
+	placed := make([][]position.Capture, len(rd.captures))
+	for i, captures := range rd.captures {
+		placed[i] = make([]position.Capture, len(captures))
+		for c, capture := range captures {
+			pos := capture.Position()
+			if rd.aabb != nil {
+				pos = rd.aabb.CenterPos(pos.X(), pos.Y(), pos.Z())
+			}
+			placed[i][c] = position.NewCapture(capture.Time(), pos.X()*2, -pos.Y()*2, pos.Z()*2)
+		}
+	}
+	return placed
 }
 
 func (rd *runningData) toRecording() format.Recording {
-	childrenRecordings := make([]format.Recording, len(rd.captures))
-	for i, col := range rd.captures {
-		styling := metadata.EmptyBlock()
-		styling.Mapping()["recolude-scale"] = metadata.NewStringProperty("0.04, 0.04, 0.04")
-		styling.Mapping()["recolude-geom"] = metadata.NewStringProperty("sphere")
-		styling.Mapping()["recolude-color"] = metadata.NewStringProperty(landmarkColors[i])
+	captures := rd.placed()
+	childrenRecordings := make([]format.Recording, len(captures))
+	for i, col := range captures {
+		childCollections := []format.CaptureCollection{
+			position.NewCollection(landmark.PositionCollectionName, col),
+		}
+		if len(rd.visibility[i]) > 0 {
+			childCollections = append(childCollections, float.NewCollection("Visibility", rd.visibility[i]))
+		}
+		if len(rd.presence[i]) > 0 {
+			childCollections = append(childCollections, float.NewCollection("Presence", rd.presence[i]))
+		}
 
 		childrenRecordings[i] = format.NewRecording(
-			strconv.Itoa(i),
-			landmarkNames[i],
-			[]format.CaptureCollection{
-				position.NewCollection("Position", col),
-			},
+			rd.idPrefix+strconv.Itoa(i),
+			rd.model.LandmarkName(i),
+			childCollections,
 			nil,
-			styling,
+			rd.model.LandmarkMetadata(i),
 			nil,
 			nil,
 		)
 	}
 
-	metadataLines := make([]metadata.Block, len(landmarkEdges))
-	for i, link := range landmarkEdges {
-		mapping := map[string]metadata.Property{
-			"starting-object-id": metadata.NewStringProperty(strconv.Itoa(int(link.X))),
-			"ending-object-id":   metadata.NewStringProperty(strconv.Itoa(int(link.Y))),
-			"color":              metadata.NewStringProperty("#00FF00"),
-			"width":              metadata.NewFloat32Property(0.01),
-		}
-		metadataLines[i] = metadata.NewBlock(mapping)
-	}
+	metadataLines := rd.model.Lines(len(captures), func(landmark int) string {
+		return rd.idPrefix + strconv.Itoa(landmark)
+	})
 	recordingMetadata := metadata.EmptyBlock()
 	recordingMetadata.Mapping()["recolude-lines"] = metadata.NewMetadataArrayProperty(metadataLines)
 
+	collections := make([]format.CaptureCollection, 0)
+	if gaps := tracking.FindGaps(rd.seen, rd.maxGap); len(gaps) > 0 {
+		collections = append(collections, event.NewCollection(tracking.CollectionName, tracking.Events(gaps)))
+	}
+	for i, captures := range rd.angles {
+		if len(captures) > 0 {
+			collections = append(collections, float.NewCollection(rd.model.Angles[i].Name, captures))
+		}
+	}
+	for i, counter := range rd.repCounters {
+		if reps := counter.repEvents(rd.repSignals[i], rd.maxGap); len(reps) > 0 {
+			collections = append(collections, event.NewCollection(rd.repNames[i], reps))
+		}
+	}
+
 	return format.NewRecording(
 		"",
 		"Pose Capture Demo",
-		[]format.CaptureCollection{},
+		collections,
 		childrenRecordings,
 		recordingMetadata,
 		nil,
@@ -185,65 +153,191 @@ func (rd *runningData) toRecording() format.Recording {
 	)
 }
 
-func (rd *runningData) runDetection(curTime float64, frame []LandMark) {
-	for i, landmark := range frame {
-		if len(rd.captures) < i+1 {
+func (rd *runningData) runDetection(curTime float64, frame []landmark.LandMark) error {
+	if err := tracking.CheckIDs(frame, rd.model); err != nil {
+		return err
+	}
+
+	if rd.smoothing != nil {
+		if len(rd.seen) > 0 && curTime-rd.seen[len(rd.seen)-1] > rd.maxGap {
+			rd.smoothing.Reset()
+		}
+		frame = rd.smoothing.Apply(curTime, frame)
+	}
+
+	if rd.aabb != nil {
+		for _, mark := range frame {
+			rd.aabb.Encompass(mark.X, mark.Y, mark.Z)
+		}
+	}
+
+	for _, landmark := range frame {
+		i := landmark.ID
+		for len(rd.captures) < i+1 {
 			rd.captures = append(rd.captures, make([]position.Capture, 0))
+			rd.visibility = append(rd.visibility, make([]float.Capture, 0))
+			rd.presence = append(rd.presence, make([]float.Capture, 0))
+		}
+		rd.captures[i] = append(rd.captures[i], position.NewCapture(curTime, landmark.X, landmark.Y, landmark.Z))
+
+		if landmark.Visibility != nil {
+			rd.visibility[i] = append(rd.visibility[i], float.NewCapture(curTime, *landmark.Visibility))
+		}
+		if landmark.Presence != nil {
+			rd.presence[i] = append(rd.presence[i], float.NewCapture(curTime, *landmark.Presence))
 		}
-		rd.captures[i] = append(rd.captures[i], position.NewCapture(curTime, landmark.X*2, -landmark.Y*2, landmark.Z*2))
 	}
+	if rd.angles != nil || len(rd.repCounters) > 0 {
+		points, found := framePoints(rd.model, frame)
+		if rd.angles != nil {
+			for i, angle := range rd.model.Angles {
+				if degrees, ok := measureAngle(angle, points, found); ok {
+					rd.angles[i] = append(rd.angles[i], float.NewCapture(curTime, degrees))
+				}
+			}
+		}
+		for i, counter := range rd.repCounters {
+			if value, ok := counter.measure(points, found); ok {
+				rd.repSignals[i] = append(rd.repSignals[i], float.NewCapture(curTime, value))
+			}
+		}
+	}
+	rd.seen = append(rd.seen, curTime)
+	return nil
 }
 
-type LandMark struct {
-	X  float64 `json:"x"`
-	Y  float64 `json:"y"`
-	Z  float64 `json:"z"`
-	ID int     `json:"id"`
+// Converter builds recordings out of frames of pose landmarks.
+type Converter struct {
+	// FrameRate is the rate the frames were captured at, used to determine
+	// when a frame occurred if it carries no timestamp of its own.
+	FrameRate float64
+
+	// MaxGap is the longest time, in seconds, the pose can go undetected
+	// before it's considered lost. Zero derives the gap from the frame rate.
+	MaxGap float64
+
+	// Smoothing builds the filters used to smooth the landmarks before
+	// they're captured. Nil leaves the landmarks untouched.
+	Smoothing filter.Factory
+
+	// Bounds, if set, is grown to encompass every landmark and used to center
+	// the pose. Left nil, the pose keeps the detector's origin. Sharing bounds
+	// between converters places their recordings in the same space.
+	Bounds *landmark.AABB
+
+	// IDPrefix is prepended to the ID of every landmark's recording, keeping
+	// IDs unique when the recording sits alongside others.
+	IDPrefix string
+
+	// Topology names, styles and connects the landmarks. Left nil, landmarks
+	// are assumed to come from mediapipe. Frames holding a landmark ID the
+	// topology doesn't define are rejected.
+	Topology *topology.Topology
+
+	// JointAngles, if set, measures each of the topology's joint angles every
+	// frame, recorded in degrees as a float collection per angle on the
+	// pose's recording.
+	JointAngles bool
+
+	// RepCounters each find the repetitions of an exercise within the pose,
+	// recorded as rep events within an event collection per counter on the
+	// pose's recording. Every event carries the repetition's start, end and
+	// duration, along with the furthest its signal got from rest as peak.
+	// Counters sharing a name have their collections told apart by their
+	// thresholds.
+	RepCounters []RepCounter
 }
 
-func (lm LandMark) String() string {
-	return fmt.Sprintf("%s: %f, %f, %f", landmarkNames[lm.ID], lm.X, lm.Y, lm.Z)
+// NewConverter creates a converter for mediapipe landmarks in frames captured
+// at the provided frame rate.
+func NewConverter(frameRate float64) Converter {
+	return Converter{FrameRate: frameRate, Topology: MediaPipe}
 }
 
-func check(err error) {
-	if err != nil {
-		panic(err)
+func (c Converter) newRunningData() *runningData {
+	rd := &runningData{
+		captures:   make([][]position.Capture, 0),
+		visibility: make([][]float.Capture, 0),
+		presence:   make([][]float.Capture, 0),
+		seen:       make([]float64, 0),
+		aabb:       c.Bounds,
+		idPrefix:   c.IDPrefix,
+		model:      c.Topology,
+		maxGap:     tracking.ResolveMaxGap(c.MaxGap, c.FrameRate),
+		frameRate:  c.FrameRate,
+	}
+	if rd.model == nil {
+		rd.model = MediaPipe
 	}
+	if c.JointAngles {
+		rd.angles = make([][]float.Capture, len(rd.model.Angles))
+	}
+	if len(c.RepCounters) > 0 {
+		rd.repCounters = c.RepCounters
+		rd.repSignals = make([][]float.Capture, len(c.RepCounters))
+		rd.repNames = repCollectionNames(c.RepCounters)
+	}
+	if c.Smoothing != nil {
+		rd.smoothing = filter.NewStage(c.Smoothing)
+	}
+	return rd
+}
+
+// Convert builds a single recording containing every pose landmark found
+// within the frames. Any stretch of time the pose went undetected is marked
+// with tracking events on the recording. Landmarks that carry visibility or
+// presence have them recorded as float collections on the landmark's
+// recording.
+func (c Converter) Convert(frames []landmark.Frame) (format.Recording, error) {
+	return c.Stream(landmark.NewSliceReader(frames))
 }
 
-func main() {
-	jsonFile, err := os.Open("ivey.json")
-	check(err)
-	defer jsonFile.Close()
+// Stream builds the same recording as Convert, but reads frames one at a
+// time, never holding onto more than a single frame.
+func (c Converter) Stream(frames landmark.FrameReader) (format.Recording, error) {
+	builder := c.NewBuilder()
+	if err := landmark.Feed(frames, c.FrameRate, builder.Add); err != nil {
+		return nil, err
+	}
+	return builder.Recording(), nil
+}
 
-	byteValue, err := ioutil.ReadAll(jsonFile)
-	check(err)
+// Builder builds a recording one frame at a time, for callers feeding the
+// converter landmarks from a source other than a FrameReader.
+type Builder struct {
+	rd *runningData
+}
 
-	// we initialize our Users array
-	var frames [][]LandMark
+// NewBuilder creates a builder that produces the same recording Convert
+// would for the frames added to it.
+func (c Converter) NewBuilder() *Builder {
+	return &Builder{rd: c.newRunningData()}
+}
 
-	// we unmarshal our byteArray which contains our
-	// jsonFile's content into 'users' which we defined above
-	check(json.Unmarshal(byteValue, &frames))
+// Add records the landmarks detected within a frame that occurred at the
+// provided time, in seconds.
+func (b *Builder) Add(curTime float64, marks []landmark.LandMark) error {
+	return b.rd.runDetection(curTime, marks)
+}
 
-	rd := &runningData{
-		captures: make([][]position.Capture, 0),
-	}
-	curTime := 0.0
+// Recording builds the recording out of every frame added so far.
+func (b *Builder) Recording() format.Recording {
+	return b.rd.toRecording()
+}
 
-	for _, frame := range frames {
-		rd.runDetection(curTime, frame)
-		curTime += 1.0 / 30.0
-	}
+// WriteBVH writes every frame added so far as a BVH motion capture file, with
+// a skeleton solved out of the landmarks. Frames are resampled at the typical
+// time between detections, so stretches the pose went undetected keep their
+// original length, but frames whose timing strayed from that step land
+// between the resampled ones and are only seen interpolated. Landmarks are
+// expected to be mediapipe's world landmarks, in meters.
+func (b *Builder) WriteBVH(out io.Writer) error {
+	return b.rd.writeBVH(out)
+}
 
-	f, _ := os.Create("pose tracking.rap")
-	recordingWriter := rapio.NewWriter(
-		[]encoding.Encoder{
-			positionEncoder.NewEncoder(positionEncoder.Oct24),
-		},
-		true,
-		f,
-		rapio.BST16,
-	)
-	recordingWriter.Write(rd.toRecording())
+// RangesOfMotion is how far each joint angle moved over every frame added so
+// far, for every angle measured at least once. Empty unless the converter
+// records joint angles.
+func (b *Builder) RangesOfMotion() []RangeOfMotion {
+	return b.rd.rangesOfMotion()
 }
@@ -47,15 +47,23 @@ def write_frames(frames, out_path):
             entry = []
             i = 0
             for mark in results.pose_world_landmarks.landmark:
-                entry.append({
+                landmark = {
                     "id": i,
                     "x": mark.x, 
                     "y": mark.y, 
                     "z": mark.z, 
-                })
+                }
+                if mark.HasField("visibility"):
+                    landmark["visibility"] = mark.visibility
+                if mark.HasField("presence"):
+                    landmark["presence"] = mark.presence
+                entry.append(landmark)
                 i += 1
 
-            data_out.append(entry)
+            data_out.append({
+                "frame": idx,
+                "landmarks": entry,
+            })
 
     with open(out_path, 'w') as outfile:
         json.dump(data_out, outfile)
@@ -116,5 +124,5 @@ def process_frames(frames, out_path):
 
 
 if __name__ == "__main__":
-    write_frames(video_image_files("frames"), "out.json")
+    write_frames(video_image_files("frames"), "pose.json")
     # process_frames(video_image_files("frames"), "frames_out")
@@ -0,0 +1,116 @@
+package pose
+
+import (
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/float"
+)
+
+func likelihood(v float64) *float64 {
+	return &v
+}
+
+// collectionValues is every value within the float collection of the name,
+// or nil if the recording has no such collection.
+func collectionValues(recording format.Recording, name string) []float64 {
+	for _, collection := range recording.CaptureCollections() {
+		if collection.Name() != name {
+			continue
+		}
+		values := make([]float64, 0)
+		for _, capture := range collection.Captures() {
+			values = append(values, capture.(float.Capture).Value())
+		}
+		return values
+	}
+	return nil
+}
+
+func TestConvertLikelihoods(t *testing.T) {
+	frames := []landmark.Frame{
+		{Landmarks: []landmark.LandMark{
+			{ID: 0, Visibility: likelihood(0.9), Presence: likelihood(0.8)},
+			{ID: 1},
+			{ID: 2, Visibility: likelihood(0.5)},
+		}},
+		{Landmarks: []landmark.LandMark{
+			{ID: 0, Visibility: likelihood(0.7)},
+			{ID: 1},
+			{ID: 2, Visibility: likelihood(0.25)},
+		}},
+	}
+
+	recording, err := NewConverter(30).Convert(frames)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		landmark   int
+		visibility []float64
+		presence   []float64
+	}{
+		{"both", 0, []float64{0.9, 0.7}, []float64{0.8}},
+		{"neither", 1, nil, nil},
+		{"visibility alone", 2, []float64{0.5, 0.25}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mark := recording.Recordings()[tc.landmark]
+			if got := len(landmark.Positions(mark)); got != 2 {
+				t.Fatalf("got %d positions, want 2", got)
+			}
+
+			for _, c := range []struct {
+				name string
+				want []float64
+			}{
+				{"Visibility", tc.visibility},
+				{"Presence", tc.presence},
+			} {
+				got := collectionValues(mark, c.name)
+				if (got == nil) != (c.want == nil) || len(got) != len(c.want) {
+					t.Fatalf("got %s %v, want %v", c.name, got, c.want)
+				}
+				for i := range got {
+					if got[i] != c.want[i] {
+						t.Fatalf("got %s %v, want %v", c.name, got, c.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuilderRejectsUnknownLandmarks(t *testing.T) {
+	tests := []struct {
+		name      string
+		converter Converter
+		id        int
+		wantErr   string
+	}{
+		{"negative", NewConverter(30), -1, "landmark ID -1 is outside of the 33 landmarks of mediapipe-pose"},
+		{"past mediapipe", NewConverter(30), 33, "landmark ID 33 is outside of the 33 landmarks of mediapipe-pose"},
+		{"past body25", Converter{FrameRate: 30, Topology: Body25}, 25, "landmark ID 25 is outside of the 25 landmarks of openpose-body25"},
+		{"past coco18", Converter{FrameRate: 30, Topology: COCO18}, 18, "landmark ID 18 is outside of the 18 landmarks of openpose-coco18"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := tc.converter.NewBuilder()
+			err := builder.Add(0, []landmark.LandMark{{ID: 0}, {ID: tc.id}})
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("got error %v, want %q", err, tc.wantErr)
+			}
+
+			// Nothing from the rejected frame is captured.
+			if got := len(builder.Recording().Recordings()); got != 0 {
+				t.Fatalf("got %d landmark recordings, want none", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package pose
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/topology"
+	"github.com/recolude/rap/format/collection/event"
+	"github.com/recolude/rap/format/collection/float"
+	"github.com/recolude/rap/format/metadata"
+)
+
+// RepEvent is the name of the event emitted at the start of every
+// repetition.
+const RepEvent = "rep"
+
+// axisNames are the names of the axes a landmark's position can be followed
+// along, indexed by axis.
+var axisNames = []string{"x", "y", "z"}
+
+// RepCounter finds the repetitions of an exercise within a signal measured
+// from the pose every frame, either a joint angle or a landmark's position
+// along a single axis. A repetition starts the last moment the signal sat at
+// Rest before heading towards Peak, and ends the moment it returns to Rest
+// after having reached Peak. Movements that turn back before reaching Peak
+// aren't counted.
+type RepCounter struct {
+	// Name is the name of the event collection the repetitions are recorded
+	// within. Left empty, the collection is named "Reps".
+	Name string
+
+	// Angle, if set, is the joint angle the signal is measured from, in
+	// degrees.
+	Angle *topology.Angle
+
+	// Landmark and Axis, used when Angle is nil, are the landmark and axis
+	// (0 for X, 1 for Y and 2 for Z) the signal is measured along, in the
+	// detector's coordinates as read from the input.
+	Landmark int
+	Axis     int
+
+	// Rest is the value the signal sits at between repetitions, and Peak the
+	// value it must reach for a repetition to count. Peak may sit either
+	// above or below Rest.
+	Rest float64
+	Peak float64
+}
+
+// ParseRepCounter builds a rep counter from a description of the signal to
+// follow and the thresholds to count repetitions with, such as "Right Knee
+// Flexion:rest=30,peak=90". The signal is either the name of one of the
+// model's joint angles, or a landmark, by name or ID, and an axis joined by a
+// dot, such as "LEFT WRIST.y" or "15.y".
+func ParseRepCounter(spec string, model *topology.Topology) (RepCounter, error) {
+	i := strings.LastIndex(spec, ":")
+	if i == -1 {
+		return RepCounter{}, fmt.Errorf("rep counter %q is missing its thresholds, such as \"%s:rest=30,peak=90\"", spec, spec)
+	}
+	signal := strings.TrimSpace(spec[:i])
+
+	counter := RepCounter{}
+	if angle, ok := model.Angle(signal); ok {
+		counter.Angle = angle
+		counter.Name = angle.Name + " Reps"
+	} else {
+		dot := strings.LastIndex(signal, ".")
+		if dot == -1 {
+			return RepCounter{}, fmt.Errorf("%q is neither a joint angle of %s nor a landmark and axis, such as \"15.y\"", signal, model.Name)
+		}
+
+		axis := -1
+		for a, name := range axisNames {
+			if strings.EqualFold(signal[dot+1:], name) {
+				axis = a
+			}
+		}
+		if axis == -1 {
+			return RepCounter{}, fmt.Errorf("unknown axis %q, must be x, y or z", signal[dot+1:])
+		}
+
+		landmark, ok := model.LandmarkID(signal[:dot])
+		if !ok {
+			id, err := strconv.Atoi(signal[:dot])
+			if err != nil || id < 0 || id >= len(model.Landmarks) {
+				return RepCounter{}, fmt.Errorf("%q is not a landmark of %s", signal[:dot], model.Name)
+			}
+			landmark = id
+		}
+
+		counter.Landmark = landmark
+		counter.Axis = axis
+		counter.Name = fmt.Sprintf("%s %s Reps", model.LandmarkName(landmark), strings.ToUpper(axisNames[axis]))
+	}
+
+	thresholds := map[string]float64{}
+	for _, pair := range strings.Split(spec[i+1:], ",") {
+		keyVal := strings.SplitN(pair, "=", 2)
+		if len(keyVal) != 2 {
+			return RepCounter{}, fmt.Errorf("rep counter parameter %q is not in the form key=value", pair)
+		}
+
+		key := strings.TrimSpace(keyVal[0])
+		if key != "rest" && key != "peak" {
+			return RepCounter{}, fmt.Errorf("unknown rep counter parameter %q", key)
+		}
+
+		val, err := strconv.ParseFloat(strings.TrimSpace(keyVal[1]), 64)
+		if err != nil {
+			return RepCounter{}, fmt.Errorf("rep counter parameter %q: %w", pair, err)
+		}
+		thresholds[key] = val
+	}
+
+	rest, hasRest := thresholds["rest"]
+	peak, hasPeak := thresholds["peak"]
+	if !hasRest || !hasPeak {
+		return RepCounter{}, fmt.Errorf("rep counter %q needs both rest and peak", spec)
+	}
+	if rest == peak {
+		return RepCounter{}, fmt.Errorf("rep counter rest and peak can not be the same, got %g", rest)
+	}
+	counter.Rest = rest
+	counter.Peak = peak
+	return counter, nil
+}
+
+// measure measures the counter's signal within a single frame, indexed by
+// landmark. ok is false if the signal can't be measured within the frame.
+func (rc RepCounter) measure(points []vector.Vector3, found []bool) (value float64, ok bool) {
+	if rc.Angle != nil {
+		return measureAngle(*rc.Angle, points, found)
+	}
+
+	if rc.Landmark < 0 || rc.Landmark >= len(found) || !found[rc.Landmark] {
+		return 0, false
+	}
+	p := points[rc.Landmark]
+	switch rc.Axis {
+	case 0:
+		return p.X(), true
+	case 1:
+		return p.Y(), true
+	case 2:
+		return p.Z(), true
+	}
+	return 0, false
+}
+
+func (rc RepCounter) collectionName() string {
+	if rc.Name == "" {
+		return "Reps"
+	}
+	return rc.Name
+}
+
+// repCollectionNames names the event collection of each counter, keeping
+// every name unique so counters sharing a name, such as ones following the
+// same signal with different thresholds, don't overwrite each other. Shared
+// names are told apart by the counters' thresholds, and counters sharing
+// those too are numbered.
+func repCollectionNames(counters []RepCounter) []string {
+	names := make([]string, len(counters))
+	counts := make(map[string]int)
+	for i, counter := range counters {
+		names[i] = counter.collectionName()
+		counts[names[i]]++
+	}
+	for i, counter := range counters {
+		if counts[names[i]] > 1 {
+			names[i] = fmt.Sprintf("%s (rest %g, peak %g)", names[i], counter.Rest, counter.Peak)
+		}
+	}
+
+	taken := make(map[string]int)
+	for i, name := range names {
+		taken[name]++
+		if taken[name] > 1 {
+			names[i] = fmt.Sprintf("%s %d", name, taken[name])
+		}
+	}
+	return names
+}
+
+// rep is a single repetition of an exercise.
+type rep struct {
+	start float64
+	end   float64
+
+	// peak is the furthest the signal got from rest during the repetition,
+	// and peakTime when it got there.
+	peak     float64
+	peakTime float64
+}
+
+// findReps looks through the signal every frame it was measured for
+// repetitions. A repetition interrupted by the signal going unmeasured for
+// longer than maxGap, or still going at the end of the clip, is dropped.
+func (rc RepCounter) findReps(signal []float.Capture, maxGap float64) []rep {
+	// Flip the signal so it always rises from rest towards the peak.
+	direction := 1.
+	if rc.Peak < rc.Rest {
+		direction = -1
+	}
+	height := func(v float64) float64 {
+		return (v - rc.Rest) * direction
+	}
+	peakHeight := height(rc.Peak)
+
+	reps := make([]rep, 0)
+	atRest := false
+	reached := false
+	current := rep{}
+	for i, capture := range signal {
+		if i > 0 && capture.Time()-signal[i-1].Time() > maxGap {
+			atRest, reached = false, false
+		}
+
+		h := height(capture.Value())
+		if h <= 0 {
+			if reached {
+				current.end = capture.Time()
+				reps = append(reps, current)
+			}
+			atRest, reached = true, false
+			current = rep{start: capture.Time(), peak: capture.Value(), peakTime: capture.Time()}
+			continue
+		}
+
+		// Only movements that began at rest are counted.
+		if !atRest {
+			continue
+		}
+		if h > height(current.peak) {
+			current.peak = capture.Value()
+			current.peakTime = capture.Time()
+		}
+		if h >= peakHeight {
+			reached = true
+		}
+	}
+	return reps
+}
+
+// repEvents builds an event for every repetition, emitted the moment the
+// repetition started.
+func (rc RepCounter) repEvents(signal []float.Capture, maxGap float64) []event.Capture {
+	reps := rc.findReps(signal, maxGap)
+	captures := make([]event.Capture, len(reps))
+	for i, r := range reps {
+		captures[i] = event.NewCapture(r.start, RepEvent, metadata.NewBlock(map[string]metadata.Property{
+			"rep":       metadata.NewIntProperty(i + 1),
+			"start":     metadata.NewFloat32Property(float32(r.start)),
+			"end":       metadata.NewFloat32Property(float32(r.end)),
+			"duration":  metadata.NewFloat32Property(float32(r.end - r.start)),
+			"peak":      metadata.NewFloat32Property(float32(r.peak)),
+			"peak-time": metadata.NewFloat32Property(float32(r.peakTime)),
+		}))
+	}
+	return captures
+}
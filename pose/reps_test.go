@@ -0,0 +1,217 @@
+package pose
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/recolude/rap/format/collection/float"
+)
+
+func TestParseRepCounter(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		wantName  string
+		wantAngle string
+		landmark  int
+		axis      int
+		rest      float64
+		peak      float64
+		wantErr   string
+	}{
+		{
+			name:      "joint angle",
+			spec:      "right knee flexion:rest=30,peak=90",
+			wantName:  "Right Knee Flexion Reps",
+			wantAngle: "Right Knee Flexion",
+			rest:      30,
+			peak:      90,
+		},
+		{
+			name:     "landmark by name",
+			spec:     "LEFT WRIST.y: rest = 0.6, peak = 0.2",
+			wantName: "LEFT WRIST Y Reps",
+			landmark: 15,
+			axis:     1,
+			rest:     0.6,
+			peak:     0.2,
+		},
+		{
+			name:     "landmark by ID",
+			spec:     "16.Z:rest=0,peak=-0.5",
+			wantName: "RIGHT WRIST Z Reps",
+			landmark: 16,
+			axis:     2,
+			rest:     0,
+			peak:     -0.5,
+		},
+		{name: "no thresholds", spec: "Left Knee Flexion", wantErr: "missing its thresholds"},
+		{name: "unknown signal", spec: "Left Tail Flexion:rest=0,peak=1", wantErr: "neither a joint angle"},
+		{name: "unknown axis", spec: "15.w:rest=0,peak=1", wantErr: "unknown axis"},
+		{name: "negative landmark", spec: "-1.y:rest=0,peak=1", wantErr: "not a landmark"},
+		{name: "landmark past the model", spec: "33.y:rest=0,peak=1", wantErr: "not a landmark"},
+		{name: "missing peak", spec: "15.y:rest=0", wantErr: "needs both rest and peak"},
+		{name: "unknown parameter", spec: "15.y:rest=0,peak=1,depth=2", wantErr: "unknown rep counter parameter"},
+		{name: "not key value", spec: "15.y:rest", wantErr: "not in the form key=value"},
+		{name: "bad number", spec: "15.y:rest=low,peak=1", wantErr: "rest=low"},
+		{name: "rest at peak", spec: "15.y:rest=1,peak=1", wantErr: "can not be the same"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			counter, err := ParseRepCounter(tc.spec, MediaPipe)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			if counter.Name != tc.wantName || counter.Rest != tc.rest || counter.Peak != tc.peak {
+				t.Fatalf("got %+v, want %q from %g to %g", counter, tc.wantName, tc.rest, tc.peak)
+			}
+			if tc.wantAngle != "" {
+				if counter.Angle == nil || counter.Angle.Name != tc.wantAngle {
+					t.Fatalf("got angle %v, want %q", counter.Angle, tc.wantAngle)
+				}
+				return
+			}
+			if counter.Angle != nil || counter.Landmark != tc.landmark || counter.Axis != tc.axis {
+				t.Fatalf("got %+v, want landmark %d along axis %d", counter, tc.landmark, tc.axis)
+			}
+		})
+	}
+}
+
+func signal(values ...float64) []float.Capture {
+	captures := make([]float.Capture, len(values))
+	for i, v := range values {
+		captures[i] = float.NewCapture(float64(i), v)
+	}
+	return captures
+}
+
+func TestRepEvents(t *testing.T) {
+	type want struct {
+		start string
+		end   string
+		peak  string
+	}
+
+	tests := []struct {
+		name    string
+		counter RepCounter
+		signal  []float.Capture
+		maxGap  float64
+		want    []want
+	}{
+		{
+			name:    "two reps",
+			counter: RepCounter{Rest: 30, Peak: 90},
+			signal:  signal(20, 60, 100, 50, 25, 40, 95, 20),
+			maxGap:  1.5,
+			want: []want{
+				{start: "0.000000", end: "4.000000", peak: "100.000000"},
+				{start: "4.000000", end: "7.000000", peak: "95.000000"},
+			},
+		},
+		{
+			name:    "peak below rest",
+			counter: RepCounter{Rest: 0.6, Peak: 0.2},
+			signal:  signal(0.7, 0.4, 0.1, 0.5, 0.65),
+			maxGap:  1.5,
+			want:    []want{{start: "0.000000", end: "4.000000", peak: "0.100000"}},
+		},
+		{
+			name:    "turned back before the peak",
+			counter: RepCounter{Rest: 30, Peak: 90},
+			signal:  signal(20, 60, 80, 20),
+			maxGap:  1.5,
+			want:    []want{},
+		},
+		{
+			name:    "never at rest first",
+			counter: RepCounter{Rest: 30, Peak: 90},
+			signal:  signal(100, 60, 20),
+			maxGap:  1.5,
+			want:    []want{},
+		},
+		{
+			name:    "unfinished at the end of the clip",
+			counter: RepCounter{Rest: 30, Peak: 90},
+			signal:  signal(20, 100, 60),
+			maxGap:  1.5,
+			want:    []want{},
+		},
+		{
+			name:    "interrupted by a gap",
+			counter: RepCounter{Rest: 30, Peak: 90},
+			signal: []float.Capture{
+				float.NewCapture(0, 20),
+				float.NewCapture(1, 100),
+				float.NewCapture(5, 20),
+			},
+			maxGap: 1.5,
+			want:   []want{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			events := tc.counter.repEvents(tc.signal, tc.maxGap)
+			if len(events) != len(tc.want) {
+				t.Fatalf("got %d reps, want %d", len(events), len(tc.want))
+			}
+			for i, w := range tc.want {
+				e := events[i]
+				mapping := e.Metadata().Mapping()
+				got := want{start: mapping["start"].String(), end: mapping["end"].String(), peak: mapping["peak"].String()}
+				if e.Name() != RepEvent || mapping["rep"].String() != strconv.Itoa(i+1) || got != w {
+					t.Fatalf("rep %d: got %s %v with %+v, want %+v", i, e.Name(), mapping["rep"], got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestRepCollectionNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		counters []RepCounter
+		want     []string
+	}{
+		{
+			name:     "unique",
+			counters: []RepCounter{{Name: "Squats"}, {}},
+			want:     []string{"Squats", "Reps"},
+		},
+		{
+			name:     "shared name",
+			counters: []RepCounter{{Name: "Squats", Rest: 30, Peak: 90}, {Name: "Squats", Rest: 30, Peak: 120}, {Name: "Curls"}},
+			want:     []string{"Squats (rest 30, peak 90)", "Squats (rest 30, peak 120)", "Curls"},
+		},
+		{
+			name:     "shared thresholds",
+			counters: []RepCounter{{Rest: 1, Peak: 2}, {Rest: 1, Peak: 2}, {Rest: 1, Peak: 2}},
+			want:     []string{"Reps (rest 1, peak 2)", "Reps (rest 1, peak 2) 2", "Reps (rest 1, peak 2) 3"},
+		},
+		{
+			name:     "numbered name already taken",
+			counters: []RepCounter{{Name: "Reps (rest 1, peak 2)"}, {Rest: 1, Peak: 2}, {Rest: 1, Peak: 2}},
+			want:     []string{"Reps (rest 1, peak 2)", "Reps (rest 1, peak 2) 2", "Reps (rest 1, peak 2) 3"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := repCollectionNames(tc.counters)
+			if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
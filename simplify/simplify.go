@@ -0,0 +1,139 @@
+// Package simplify reduces the number of captures within a recording by
+// dropping any capture that can be recreated by interpolating between the
+// captures around it.
+package simplify
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/vector"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// Report is how many position captures a recording had before and after
+// being simplified.
+type Report struct {
+	Before int
+	After  int
+}
+
+// Ratio is the fraction of captures that remain after simplification.
+func (r Report) Ratio() float64 {
+	if r.Before == 0 {
+		return 1
+	}
+	return float64(r.After) / float64(r.Before)
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf("%d -> %d captures (%.1f%%)", r.Before, r.After, r.Ratio()*100)
+}
+
+// Captures runs Ramer-Douglas-Peucker over the captures, treating time as the
+// curve's parameter. A capture is dropped if linearly interpolating between
+// the captures kept on either side of it lands within epsilon of its
+// position. The first and last capture are always kept.
+func Captures(captures []position.Capture, epsilon float64) []position.Capture {
+	if len(captures) < 3 {
+		return captures
+	}
+
+	keep := make([]bool, len(captures))
+	keep[0] = true
+	keep[len(captures)-1] = true
+
+	type segment struct {
+		start int
+		end   int
+	}
+
+	stack := []segment{{start: 0, end: len(captures) - 1}}
+	for len(stack) > 0 {
+		seg := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		furthest := -1
+		furthestDistance := epsilon
+		for i := seg.start + 1; i < seg.end; i++ {
+			distance := interpolate(captures[seg.start], captures[seg.end], captures[i].Time()).
+				Distance(captures[i].Position())
+			if distance > furthestDistance {
+				furthest = i
+				furthestDistance = distance
+			}
+		}
+
+		if furthest == -1 {
+			continue
+		}
+
+		keep[furthest] = true
+		stack = append(stack, segment{seg.start, furthest}, segment{furthest, seg.end})
+	}
+
+	simplified := make([]position.Capture, 0)
+	for i, capture := range captures {
+		if keep[i] {
+			simplified = append(simplified, capture)
+		}
+	}
+	return simplified
+}
+
+func interpolate(start, end position.Capture, t float64) vector.Vector3 {
+	duration := end.Time() - start.Time()
+	if duration <= 0 {
+		return start.Position()
+	}
+	progress := (t - start.Time()) / duration
+	return start.Position().Add(end.Position().Sub(start.Position()).MultByConstant(progress))
+}
+
+// Recording rebuilds the recording and all of its children with the Position
+// collection of every landmark simplified by Captures. Collections derived
+// from the landmarks, such as a head's position or a gaze's direction, are
+// measured in other units and left untouched.
+func Recording(recording format.Recording, epsilon float64) (format.Recording, Report) {
+	report := Report{}
+
+	_, isLandmark := landmark.ID(recording)
+	collections := make([]format.CaptureCollection, len(recording.CaptureCollections()))
+	for i, collection := range recording.CaptureCollections() {
+		positions, ok := collection.(position.Collection)
+		if !ok || !isLandmark || positions.Name() != landmark.PositionCollectionName {
+			collections[i] = collection
+			continue
+		}
+
+		original := make([]position.Capture, positions.Length())
+		for c := range original {
+			original[c] = positions.CaptureAt(c).(position.Capture)
+		}
+
+		simplified := Captures(original, epsilon)
+		report.Before += len(original)
+		report.After += len(simplified)
+		collections[i] = position.NewCollection(positions.Name(), simplified)
+	}
+
+	children := make([]format.Recording, len(recording.Recordings()))
+	for i, child := range recording.Recordings() {
+		var childReport Report
+		children[i], childReport = Recording(child, epsilon)
+		report.Before += childReport.Before
+		report.After += childReport.After
+	}
+
+	return format.NewRecording(
+		recording.ID(),
+		recording.Name(),
+		collections,
+		children,
+		recording.Metadata(),
+		recording.Binaries(),
+		recording.BinaryReferences(),
+	), report
+}
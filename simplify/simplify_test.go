@@ -0,0 +1,171 @@
+package simplify
+
+import (
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/position"
+	"github.com/recolude/rap/format/metadata"
+)
+
+func captures(points ...[4]float64) []position.Capture {
+	out := make([]position.Capture, len(points))
+	for i, p := range points {
+		out[i] = position.NewCapture(p[0], p[1], p[2], p[3])
+	}
+	return out
+}
+
+func times(captures []position.Capture) []float64 {
+	out := make([]float64, len(captures))
+	for i, capture := range captures {
+		out[i] = capture.Time()
+	}
+	return out
+}
+
+func TestCaptures(t *testing.T) {
+	tests := []struct {
+		name     string
+		captures []position.Capture
+		epsilon  float64
+		want     []float64
+	}{
+		{
+			name:     "too few to simplify",
+			captures: captures([4]float64{0, 0, 0, 0}, [4]float64{1, 1, 0, 0}),
+			epsilon:  1,
+			want:     []float64{0, 1},
+		},
+		{
+			name: "straight line",
+			captures: captures(
+				[4]float64{0, 0, 0, 0},
+				[4]float64{1, 1, 0, 0},
+				[4]float64{2, 2, 0, 0},
+				[4]float64{3, 3, 0, 0},
+			),
+			epsilon: 0.01,
+			want:    []float64{0, 3},
+		},
+		{
+			name: "standing still",
+			captures: captures(
+				[4]float64{0, 1, 2, 3},
+				[4]float64{1, 1, 2, 3},
+				[4]float64{2, 1, 2, 3},
+			),
+			epsilon: 0,
+			want:    []float64{0, 2},
+		},
+		{
+			name: "corner kept",
+			captures: captures(
+				[4]float64{0, 0, 0, 0},
+				[4]float64{1, 1, 0, 0},
+				[4]float64{2, 2, 0, 0},
+				[4]float64{3, 1, 0, 0},
+				[4]float64{4, 0, 0, 0},
+			),
+			epsilon: 0.1,
+			want:    []float64{0, 2, 4},
+		},
+		{
+			name: "bump within epsilon dropped",
+			captures: captures(
+				[4]float64{0, 0, 0, 0},
+				[4]float64{1, 0, 0.05, 0},
+				[4]float64{2, 0, 0, 0},
+			),
+			epsilon: 0.1,
+			want:    []float64{0, 2},
+		},
+		{
+			name: "bump beyond epsilon kept",
+			captures: captures(
+				[4]float64{0, 0, 0, 0},
+				[4]float64{1, 0, 0.5, 0},
+				[4]float64{2, 0, 0, 0},
+			),
+			epsilon: 0.1,
+			want:    []float64{0, 1, 2},
+		},
+		{
+			name: "interpolated by time rather than index",
+			captures: captures(
+				[4]float64{0, 0, 0, 0},
+				[4]float64{1, 1, 0, 0},
+				[4]float64{4, 4, 0, 0},
+			),
+			epsilon: 0.01,
+			want:    []float64{0, 4},
+		},
+		{
+			name: "uneven timing kept",
+			captures: captures(
+				[4]float64{0, 0, 0, 0},
+				[4]float64{3, 1, 0, 0},
+				[4]float64{4, 4, 0, 0},
+			),
+			epsilon: 0.01,
+			want:    []float64{0, 3, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := times(Captures(tc.captures, tc.epsilon))
+			if len(got) != len(tc.want) {
+				t.Fatalf("got captures at %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got captures at %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordingOnlySimplifiesLandmarks(t *testing.T) {
+	line := captures(
+		[4]float64{0, 0, 0, 0},
+		[4]float64{1, 1, 0, 0},
+		[4]float64{2, 2, 0, 0},
+	)
+
+	landmarkMetadata := metadata.EmptyBlock()
+	landmarkMetadata.Mapping()[landmark.IDMetadataKey] = metadata.NewIntProperty(0)
+	mark := format.NewRecording("0", "NOSE", []format.CaptureCollection{
+		position.NewCollection(landmark.PositionCollectionName, line),
+	}, nil, landmarkMetadata, nil, nil)
+	ray := format.NewRecording("face-0-gaze-left", "Left Gaze", []format.CaptureCollection{
+		position.NewCollection(landmark.PositionCollectionName, line),
+	}, nil, metadata.EmptyBlock(), nil, nil)
+	face := format.NewRecording("face-0", "Face 0", []format.CaptureCollection{
+		position.NewCollection(landmark.PositionCollectionName, line),
+	}, []format.Recording{mark, ray}, metadata.EmptyBlock(), nil, nil)
+
+	simplified, report := Recording(face, 0.01)
+	if report.Before != 3 || report.After != 2 {
+		t.Fatalf("got report %v, want 3 -> 2 captures", report)
+	}
+
+	tests := []struct {
+		name      string
+		recording format.Recording
+		want      int
+	}{
+		{"subject left untouched", simplified, 3},
+		{"landmark simplified", simplified.Recordings()[0], 2},
+		{"gaze ray left untouched", simplified.Recordings()[1], 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := len(landmark.Positions(tc.recording)); got != tc.want {
+				t.Fatalf("got %d captures, want %d", got, tc.want)
+			}
+		})
+	}
+}
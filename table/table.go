@@ -0,0 +1,188 @@
+// Package table flattens the landmark trajectories within a recording into
+// rows, for tools that can't read recordings directly.
+package table
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// Layout is how the trajectories are laid out across rows and columns.
+type Layout string
+
+const (
+	// Long writes a row per capture of every landmark, with the columns time,
+	// subject, landmark_id, landmark_name, x, y and z.
+	Long Layout = "long"
+
+	// Wide writes a row per point in time, with an x, y and z column for
+	// every landmark of every subject. Landmarks not captured at that time
+	// are left empty.
+	Wide Layout = "wide"
+)
+
+// ParseLayout finds the layout with the provided name.
+func ParseLayout(name string) (Layout, error) {
+	switch Layout(name) {
+	case Long, Wide:
+		return Layout(name), nil
+	}
+	return "", fmt.Errorf("unknown table layout %q", name)
+}
+
+// series is the trajectory of a single landmark.
+type series struct {
+	subject  string
+	id       int
+	name     string
+	captures []position.Capture
+}
+
+// collect finds every landmark within the recording, which are the children
+// the converters built for a single landmark each. A landmark's subject is
+// the recording it's a child of, and its ID is the ID within its model the
+// converters stored on its recording. Anything else carrying positions, such
+// as a head's pose or a line drawn along a gaze, is left out.
+func collect(recording format.Recording) []series {
+	all := make([]series, 0)
+
+	subject := recording.ID()
+	if subject == "" {
+		subject = recording.Name()
+	}
+
+	for _, child := range recording.Recordings() {
+		id, ok := landmark.ID(child)
+		if !ok {
+			all = append(all, collect(child)...)
+			continue
+		}
+		all = append(all, series{
+			subject:  subject,
+			id:       id,
+			name:     child.Name(),
+			captures: landmark.Positions(child),
+		})
+	}
+	return all
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Write writes the trajectory of every landmark within the recording as CSV
+// in the requested layout.
+func Write(out io.Writer, recording format.Recording, layout Layout) error {
+	all := collect(recording)
+
+	w := csv.NewWriter(out)
+	switch layout {
+	case Long:
+		writeLong(w, all)
+
+	case Wide:
+		writeWide(w, all)
+
+	default:
+		return fmt.Errorf("unknown table layout %q", layout)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func writeLong(w *csv.Writer, all []series) {
+	type row struct {
+		time   float64
+		series int
+		pos    position.Capture
+	}
+
+	rows := make([]row, 0)
+	for s, landmark := range all {
+		for _, capture := range landmark.captures {
+			rows = append(rows, row{time: capture.Time(), series: s, pos: capture})
+		}
+	}
+
+	// Rows are ordered by time, keeping landmarks in the order they appear
+	// within the recording for captures that happened at the same time.
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].time != rows[j].time {
+			return rows[i].time < rows[j].time
+		}
+		return rows[i].series < rows[j].series
+	})
+
+	w.Write([]string{"time", "subject", "landmark_id", "landmark_name", "x", "y", "z"})
+	for _, r := range rows {
+		landmark := all[r.series]
+		pos := r.pos.Position()
+		w.Write([]string{
+			formatFloat(r.time),
+			landmark.subject,
+			strconv.Itoa(landmark.id),
+			landmark.name,
+			formatFloat(pos.X()),
+			formatFloat(pos.Y()),
+			formatFloat(pos.Z()),
+		})
+	}
+}
+
+func writeWide(w *csv.Writer, all []series) {
+	header := []string{"time"}
+	for _, landmark := range all {
+		prefix := fmt.Sprintf("%s.%d.%s.", landmark.subject, landmark.id, landmark.name)
+		header = append(header, prefix+"x", prefix+"y", prefix+"z")
+	}
+	w.Write(header)
+
+	times := make([]float64, 0)
+	seen := make(map[float64]bool)
+	for _, landmark := range all {
+		for _, capture := range landmark.captures {
+			if !seen[capture.Time()] {
+				seen[capture.Time()] = true
+				times = append(times, capture.Time())
+			}
+		}
+	}
+	sort.Float64s(times)
+
+	// With each landmark's captures ordered by time, a cursor per landmark
+	// walks them alongside the rows. A landmark captured more than once at
+	// the same time, from frames sharing a timestamp, has its last capture
+	// at that time written.
+	for _, landmark := range all {
+		sort.SliceStable(landmark.captures, func(i, j int) bool {
+			return landmark.captures[i].Time() < landmark.captures[j].Time()
+		})
+	}
+	cursors := make([]int, len(all))
+	for _, t := range times {
+		record := make([]string, 1, len(header))
+		record[0] = formatFloat(t)
+		for s, landmark := range all {
+			var captured *position.Capture
+			for cursors[s] < len(landmark.captures) && landmark.captures[cursors[s]].Time() <= t {
+				captured = &landmark.captures[cursors[s]]
+				cursors[s]++
+			}
+			if captured == nil || captured.Time() != t {
+				record = append(record, "", "", "")
+				continue
+			}
+			pos := captured.Position()
+			record = append(record, formatFloat(pos.X()), formatFloat(pos.Y()), formatFloat(pos.Z()))
+		}
+		w.Write(record)
+	}
+}
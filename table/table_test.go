@@ -0,0 +1,149 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/position"
+	"github.com/recolude/rap/format/metadata"
+)
+
+// landmarkRecording builds the recording a converter would for a landmark
+// with the ID, captured at each of the points.
+func landmarkRecording(id int, name string, points ...[4]float64) format.Recording {
+	captures := make([]position.Capture, len(points))
+	for i, p := range points {
+		captures[i] = position.NewCapture(p[0], p[1], p[2], p[3])
+	}
+
+	block := metadata.EmptyBlock()
+	block.Mapping()[landmark.IDMetadataKey] = metadata.NewIntProperty(id)
+	return format.NewRecording("mark-"+name, name, []format.CaptureCollection{
+		position.NewCollection(landmark.PositionCollectionName, captures),
+	}, nil, block, nil, nil)
+}
+
+func subjectRecording(id string, children ...format.Recording) format.Recording {
+	return format.NewRecording(id, "Subject "+id, nil, children, metadata.EmptyBlock(), nil, nil)
+}
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Layout
+		wantErr bool
+	}{
+		{"long", Long, false},
+		{"wide", Wide, false},
+		{"tall", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseLayout(tc.name)
+			if (err != nil) != tc.wantErr || got != tc.want {
+				t.Fatalf("got %q and error %v, want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	// A line drawn out of a landmark carries positions, but no landmark ID.
+	ray := format.NewRecording("ray", "Gaze", []format.CaptureCollection{
+		position.NewCollection(landmark.PositionCollectionName, []position.Capture{position.NewCapture(0, 9, 9, 9)}),
+	}, nil, metadata.EmptyBlock(), nil, nil)
+
+	tests := []struct {
+		name      string
+		recording format.Recording
+		layout    Layout
+		want      string
+	}{
+		{
+			name: "long",
+			recording: subjectRecording("pose",
+				landmarkRecording(0, "NOSE", [4]float64{0, 1, 2, 3}, [4]float64{0.5, 4, 5, 6}),
+				landmarkRecording(7, "EAR", [4]float64{0, 0.5, 0, 0}),
+			),
+			layout: Long,
+			want: "time,subject,landmark_id,landmark_name,x,y,z\n" +
+				"0,pose,0,NOSE,1,2,3\n" +
+				"0,pose,7,EAR,0.5,0,0\n" +
+				"0.5,pose,0,NOSE,4,5,6\n",
+		},
+		{
+			name: "long nested subjects",
+			recording: subjectRecording("face",
+				subjectRecording("face-0", landmarkRecording(1, "A", [4]float64{1, 0, 0, 0}), ray),
+				subjectRecording("face-1", landmarkRecording(1, "A", [4]float64{0, 2, 0, 0})),
+			),
+			layout: Long,
+			want: "time,subject,landmark_id,landmark_name,x,y,z\n" +
+				"0,face-1,1,A,2,0,0\n" +
+				"1,face-0,1,A,0,0,0\n",
+		},
+		{
+			name: "wide",
+			recording: subjectRecording("pose",
+				landmarkRecording(0, "NOSE", [4]float64{0, 1, 2, 3}, [4]float64{0.5, 4, 5, 6}),
+				landmarkRecording(7, "EAR", [4]float64{0.5, 7, 8, 9}),
+			),
+			layout: Wide,
+			want: "time,pose.0.NOSE.x,pose.0.NOSE.y,pose.0.NOSE.z,pose.7.EAR.x,pose.7.EAR.y,pose.7.EAR.z\n" +
+				"0,1,2,3,,,\n" +
+				"0.5,4,5,6,7,8,9\n",
+		},
+		{
+			name: "wide duplicate timestamps",
+			recording: subjectRecording("pose",
+				landmarkRecording(0, "NOSE", [4]float64{0, 1, 1, 1}, [4]float64{0.5, 2, 2, 2}, [4]float64{0.5, 3, 3, 3}, [4]float64{1, 4, 4, 4}),
+				landmarkRecording(1, "EYE", [4]float64{0.5, 5, 5, 5}, [4]float64{1, 6, 6, 6}),
+			),
+			layout: Wide,
+			want: "time,pose.0.NOSE.x,pose.0.NOSE.y,pose.0.NOSE.z,pose.1.EYE.x,pose.1.EYE.y,pose.1.EYE.z\n" +
+				"0,1,1,1,,,\n" +
+				"0.5,3,3,3,5,5,5\n" +
+				"1,4,4,4,6,6,6\n",
+		},
+		{
+			name: "wide out of order captures",
+			recording: subjectRecording("pose",
+				landmarkRecording(0, "NOSE", [4]float64{1, 2, 2, 2}, [4]float64{0, 1, 1, 1}),
+			),
+			layout: Wide,
+			want: "time,pose.0.NOSE.x,pose.0.NOSE.y,pose.0.NOSE.z\n" +
+				"0,1,1,1\n" +
+				"1,2,2,2\n",
+		},
+		{
+			name:      "wide without landmarks",
+			recording: subjectRecording("pose", ray),
+			layout:    Wide,
+			want:      "time\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := bytes.Buffer{}
+			if err := Write(&out, tc.recording, tc.layout); err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if out.String() != tc.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", out.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteUnknownLayout(t *testing.T) {
+	err := Write(&bytes.Buffer{}, subjectRecording("pose"), "tall")
+	if err == nil || !strings.Contains(err.Error(), "unknown table layout") {
+		t.Fatalf("got error %v, want one about the layout", err)
+	}
+}
@@ -0,0 +1,363 @@
+// Package topology describes landmark models declaratively: what each
+// landmark is called, how it's drawn, how landmarks connect to one another,
+// and the joint angles measured between them. Topologies are read from json,
+// either built in or loaded at runtime, so models can be added or restyled
+// without recompiling.
+package topology
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format/metadata"
+)
+
+//go:embed *.json
+var builtin embed.FS
+
+// Style is how a landmark is drawn. Unset fields fall back to the topology's
+// defaults.
+type Style struct {
+	// Color is the hex color the landmark is drawn with, such as "#FF0000".
+	Color string `json:"color,omitempty"`
+
+	// Geometry is the shape the landmark is drawn as, such as "sphere", or
+	// "none" to hide it.
+	Geometry string `json:"geometry,omitempty"`
+
+	// Scale is the size the landmark's geometry is drawn at.
+	Scale float64 `json:"scale,omitempty"`
+
+	// Metadata is any additional metadata to attach to the landmark's
+	// recording.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Landmark is a single point reported by the detector.
+type Landmark struct {
+	Name string `json:"name"`
+	Style
+}
+
+// Edge is a line drawn between two landmarks.
+type Edge struct {
+	From  int     `json:"from"`
+	To    int     `json:"to"`
+	Color string  `json:"color,omitempty"`
+	Width float64 `json:"width,omitempty"`
+}
+
+// Point is a position measured from landmarks, either a single landmark or
+// the average of several, written in json as a landmark's ID or a list of
+// IDs.
+type Point []int
+
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var id int
+	if err := json.Unmarshal(data, &id); err == nil {
+		*p = Point{id}
+		return nil
+	}
+
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return fmt.Errorf("point must be a landmark ID or a list of them: %w", err)
+	}
+	if len(ids) == 0 {
+		return errors.New("point must reference at least one landmark")
+	}
+	*p = ids
+	return nil
+}
+
+// Angle is a joint angle measured between two segments of the body, each
+// running from its first point to its second. The angle is zero when both
+// segments point the same way, such as an arm held straight, and grows to 180
+// degrees as one folds back over the other. Angles are unsigned, so bending a
+// joint either way from straight reads the same.
+type Angle struct {
+	Name string   `json:"name"`
+	From [2]Point `json:"from"`
+	To   [2]Point `json:"to"`
+
+	// Plane, if set, is the plane both segments are flattened into before
+	// they're measured, separating movement in one plane from another, such
+	// as flexion from abduction. Two points give the plane perpendicular to
+	// the line between them, and three give the plane running through them.
+	Plane []Point `json:"plane,omitempty"`
+}
+
+// Landmarks is every landmark the angle is measured from.
+func (a Angle) Landmarks() []int {
+	landmarks := make([]int, 0)
+	for _, point := range append([]Point{a.From[0], a.From[1], a.To[0], a.To[1]}, a.Plane...) {
+		landmarks = append(landmarks, point...)
+	}
+	return landmarks
+}
+
+// Defaults are used for any style a landmark or edge leaves unset.
+type Defaults struct {
+	Style
+	EdgeColor string  `json:"edge-color,omitempty"`
+	EdgeWidth float64 `json:"edge-width,omitempty"`
+}
+
+// Topology describes every landmark a detector reports, indexed by the
+// landmark's ID.
+type Topology struct {
+	Name      string     `json:"name"`
+	Defaults  Defaults   `json:"defaults"`
+	Landmarks []Landmark `json:"landmarks"`
+	Edges     []Edge     `json:"edges,omitempty"`
+
+	// Triangles, if set, are triplets of landmarks that make up a mesh
+	// with the landmarks as its vertices.
+	Triangles [][3]int `json:"triangles,omitempty"`
+
+	// Angles, if set, are the joint angles measured between landmarks.
+	Angles []Angle `json:"angles,omitempty"`
+}
+
+// Parse reads and validates a topology written as json.
+func Parse(r io.Reader) (*Topology, error) {
+	t := &Topology{}
+	if err := json.NewDecoder(r).Decode(t); err != nil {
+		return nil, err
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Load reads the topology within the file.
+func Load(path string) (*Topology, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Builtin reads one of the topologies that ship with the package by name,
+// such as "mediapipe-pose".
+func Builtin(name string) (*Topology, error) {
+	f, err := builtin.Open(name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no built in topology named %q", name)
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// MustBuiltin reads a built in topology, panicking if it's missing or
+// invalid. Meant for initializing package level variables.
+func MustBuiltin(name string) *Topology {
+	t, err := Builtin(name)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Resolve reads the topology at the path if it names a json file, otherwise
+// the built in topology with that name.
+func Resolve(nameOrPath string) (*Topology, error) {
+	if strings.HasSuffix(strings.ToLower(nameOrPath), ".json") {
+		return Load(nameOrPath)
+	}
+	return Builtin(nameOrPath)
+}
+
+func (t *Topology) validate() error {
+	if len(t.Landmarks) == 0 {
+		return fmt.Errorf("topology %q has no landmarks", t.Name)
+	}
+
+	inRange := func(i int) bool {
+		return i >= 0 && i < len(t.Landmarks)
+	}
+
+	for i, edge := range t.Edges {
+		if !inRange(edge.From) || !inRange(edge.To) {
+			return fmt.Errorf("edge %d (%d to %d) references a landmark outside of the %d defined", i, edge.From, edge.To, len(t.Landmarks))
+		}
+	}
+
+	for i, tri := range t.Triangles {
+		for _, vert := range tri {
+			if !inRange(vert) {
+				return fmt.Errorf("triangle %d references landmark %d outside of the %d defined", i, vert, len(t.Landmarks))
+			}
+		}
+	}
+
+	for _, angle := range t.Angles {
+		if angle.Name == "" {
+			return fmt.Errorf("topology %q has an angle with no name", t.Name)
+		}
+		if len(angle.From[0]) == 0 || len(angle.From[1]) == 0 || len(angle.To[0]) == 0 || len(angle.To[1]) == 0 {
+			return fmt.Errorf("angle %q must have both a from and a to segment", angle.Name)
+		}
+		if len(angle.Plane) != 0 && len(angle.Plane) != 2 && len(angle.Plane) != 3 {
+			return fmt.Errorf("angle %q's plane must be given by 2 or 3 points, got %d", angle.Name, len(angle.Plane))
+		}
+		for _, landmark := range angle.Landmarks() {
+			if !inRange(landmark) {
+				return fmt.Errorf("angle %q references landmark %d outside of the %d defined", angle.Name, landmark, len(t.Landmarks))
+			}
+		}
+	}
+	return nil
+}
+
+// Angle finds the joint angle with the name, ignoring case.
+func (t *Topology) Angle(name string) (*Angle, bool) {
+	for i, angle := range t.Angles {
+		if strings.EqualFold(angle.Name, name) {
+			return &t.Angles[i], true
+		}
+	}
+	return nil, false
+}
+
+// LandmarkID finds the ID of the landmark with the name, ignoring case.
+func (t *Topology) LandmarkID(name string) (int, bool) {
+	for i, landmark := range t.Landmarks {
+		if strings.EqualFold(landmark.Name, name) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// LandmarkName is the name of the landmark with the ID, or the ID itself for
+// landmarks the topology doesn't define.
+func (t *Topology) LandmarkName(id int) string {
+	if id >= 0 && id < len(t.Landmarks) && t.Landmarks[id].Name != "" {
+		return t.Landmarks[id].Name
+	}
+	return fmt.Sprint(id)
+}
+
+// LandmarkStyle is how the landmark with the ID is drawn, falling back to the
+// defaults for anything it leaves unset.
+func (t *Topology) LandmarkStyle(id int) Style {
+	style := t.Defaults.Style
+	if id < 0 || id >= len(t.Landmarks) {
+		return style
+	}
+
+	own := t.Landmarks[id].Style
+	if own.Color != "" {
+		style.Color = own.Color
+	}
+	if own.Geometry != "" {
+		style.Geometry = own.Geometry
+	}
+	if own.Scale != 0 {
+		style.Scale = own.Scale
+	}
+	if len(own.Metadata) > 0 {
+		merged := make(map[string]string, len(style.Metadata)+len(own.Metadata))
+		for key, value := range style.Metadata {
+			merged[key] = value
+		}
+		for key, value := range own.Metadata {
+			merged[key] = value
+		}
+		style.Metadata = merged
+	}
+	return style
+}
+
+// LandmarkMetadata builds the metadata the recolude player uses to draw the
+// landmark with the ID, along with the ID itself.
+func (t *Topology) LandmarkMetadata(id int) metadata.Block {
+	style := t.LandmarkStyle(id)
+
+	block := metadata.EmptyBlock()
+	if style.Scale != 0 {
+		block.Mapping()["recolude-scale"] = metadata.NewStringProperty(fmt.Sprintf("%g, %g, %g", style.Scale, style.Scale, style.Scale))
+	}
+	if style.Color != "" {
+		block.Mapping()["recolude-color"] = metadata.NewStringProperty(style.Color)
+	}
+	if style.Geometry != "" {
+		block.Mapping()["recolude-geom"] = metadata.NewStringProperty(style.Geometry)
+	}
+	for key, value := range style.Metadata {
+		block.Mapping()[key] = metadata.NewStringProperty(value)
+	}
+	block.Mapping()[landmark.IDMetadataKey] = metadata.NewIntProperty(id)
+	return block
+}
+
+// Lines builds the recolude-lines entries drawing every edge between
+// landmarks that were captured. Landmarks are captured if their ID is less
+// than captured, and the recording of each is identified by id.
+func (t *Topology) Lines(captured int, id func(landmark int) string) []metadata.Block {
+	lines := make([]metadata.Block, 0, len(t.Edges))
+	for _, edge := range t.Edges {
+		if edge.From >= captured || edge.To >= captured {
+			continue
+		}
+
+		color := edge.Color
+		if color == "" {
+			color = t.Defaults.EdgeColor
+		}
+		width := edge.Width
+		if width == 0 {
+			width = t.Defaults.EdgeWidth
+		}
+
+		lines = append(lines, metadata.NewBlock(map[string]metadata.Property{
+			"starting-object-id": metadata.NewStringProperty(id(edge.From)),
+			"ending-object-id":   metadata.NewStringProperty(id(edge.To)),
+			"color":              metadata.NewStringProperty(color),
+			"width":              metadata.NewFloat32Property(float32(width)),
+		}))
+	}
+	return lines
+}
+
+// MeshVertices is how many landmarks must be captured for the mesh to be
+// built, which is one past the highest landmark any triangle references.
+func (t *Topology) MeshVertices() int {
+	count := 0
+	for _, tri := range t.Triangles {
+		for _, vert := range tri {
+			if vert+1 > count {
+				count = vert + 1
+			}
+		}
+	}
+	return count
+}
+
+// Mesh builds the recolude-meshes entry for the triangles, treating each
+// landmark's recording, identified by id, as a vertex.
+func (t *Topology) Mesh(id func(landmark int) string) metadata.Block {
+	tris := make([]string, 0, len(t.Triangles)*3)
+	for _, tri := range t.Triangles {
+		tris = append(tris, id(tri[0]), id(tri[1]), id(tri[2]))
+	}
+	return metadata.NewBlock(map[string]metadata.Property{
+		"type": metadata.NewStringProperty("subject-as-vertices"),
+		"tris": metadata.NewStringArrayProperty(tris),
+	})
+}
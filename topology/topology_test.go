@@ -0,0 +1,305 @@
+package topology
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/rap/format"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+func TestBuiltin(t *testing.T) {
+	tests := []struct {
+		name      string
+		landmarks int
+	}{
+		{"mediapipe-pose", 33},
+		{"mediapipe-face", 478},
+		{"mediapipe-face-contours", 478},
+		{"mediapipe-hands", 21},
+		{"openpose-body25", 25},
+		{"openpose-coco18", 18},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			model, err := Builtin(tc.name)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if model.Name != tc.name {
+				t.Fatalf("got topology named %q", model.Name)
+			}
+			if len(model.Landmarks) != tc.landmarks {
+				t.Fatalf("got %d landmarks, want %d", len(model.Landmarks), tc.landmarks)
+			}
+		})
+	}
+
+	if _, err := Builtin("mediapipe-tail"); err == nil {
+		t.Fatalf("got no error reading a topology that doesn't exist")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			json: `{"name": "arm", "landmarks": [{"name": "A"}, {"name": "B"}, {"name": "C"}],
+				"edges": [{"from": 0, "to": 1}], "triangles": [[0, 1, 2]],
+				"angles": [{"name": "Bend", "from": [0, 1], "to": [1, [1, 2]], "plane": [0, 1, 2]}]}`,
+		},
+		{
+			name:    "no landmarks",
+			json:    `{"name": "empty", "landmarks": []}`,
+			wantErr: "has no landmarks",
+		},
+		{
+			name:    "edge out of range",
+			json:    `{"landmarks": [{"name": "A"}], "edges": [{"from": 0, "to": 1}]}`,
+			wantErr: "edge 0",
+		},
+		{
+			name:    "negative triangle vertex",
+			json:    `{"landmarks": [{"name": "A"}], "triangles": [[0, 0, -1]]}`,
+			wantErr: "landmark -1",
+		},
+		{
+			name:    "unnamed angle",
+			json:    `{"landmarks": [{"name": "A"}], "angles": [{"from": [0, 0], "to": [0, 0]}]}`,
+			wantErr: "angle with no name",
+		},
+		{
+			name:    "angle missing a segment",
+			json:    `{"landmarks": [{"name": "A"}], "angles": [{"name": "Bend", "from": [0, 0]}]}`,
+			wantErr: "both a from and a to segment",
+		},
+		{
+			name:    "plane of one point",
+			json:    `{"landmarks": [{"name": "A"}], "angles": [{"name": "Bend", "from": [0, 0], "to": [0, 0], "plane": [0]}]}`,
+			wantErr: "2 or 3 points",
+		},
+		{
+			name:    "angle out of range",
+			json:    `{"landmarks": [{"name": "A"}], "angles": [{"name": "Bend", "from": [0, [0, 4]], "to": [0, 0]}]}`,
+			wantErr: "landmark 4",
+		},
+		{
+			name:    "empty point",
+			json:    `{"landmarks": [{"name": "A"}], "angles": [{"name": "Bend", "from": [0, []], "to": [0, 0]}]}`,
+			wantErr: "at least one landmark",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tc.json))
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("got error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Custom.JSON")
+	if err := ioutil.WriteFile(path, []byte(`{"name": "custom", "landmarks": [{"name": "A"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{"file", path, "custom", false},
+		{"builtin", "mediapipe-hands", "mediapipe-hands", false},
+		{"missing file", filepath.Join(t.TempDir(), "missing.json"), "", true},
+		{"missing builtin", "missing", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			model, err := Resolve(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tc.wantErr)
+			}
+			if err == nil && model.Name != tc.want {
+				t.Fatalf("got topology %q, want %q", model.Name, tc.want)
+			}
+		})
+	}
+}
+
+func TestLookups(t *testing.T) {
+	model := MustBuiltin("mediapipe-pose")
+
+	if id, ok := model.LandmarkID("left knee"); !ok || id != 25 {
+		t.Fatalf("got landmark %d (%v) for left knee, want 25", id, ok)
+	}
+	if _, ok := model.LandmarkID("left tail"); ok {
+		t.Fatalf("found a landmark that doesn't exist")
+	}
+	if angle, ok := model.Angle("LEFT KNEE FLEXION"); !ok || angle.Name != "Left Knee Flexion" {
+		t.Fatalf("got angle %v (%v), want Left Knee Flexion", angle, ok)
+	}
+	if _, ok := model.Angle("Left Tail Flexion"); ok {
+		t.Fatalf("found an angle that doesn't exist")
+	}
+
+	names := []struct {
+		id   int
+		want string
+	}{
+		{0, "NOSE"},
+		{-1, "-1"},
+		{33, "33"},
+	}
+	for _, tc := range names {
+		if got := model.LandmarkName(tc.id); got != tc.want {
+			t.Fatalf("got name %q for landmark %d, want %q", got, tc.id, tc.want)
+		}
+	}
+}
+
+func TestLandmarkMetadata(t *testing.T) {
+	model, err := Parse(strings.NewReader(`{
+		"defaults": {"color": "#FFFFFF", "geometry": "sphere", "scale": 0.5, "metadata": {"shared": "default", "kept": "yes"}},
+		"landmarks": [
+			{"name": "A"},
+			{"name": "B", "color": "#FF0000", "scale": 2, "metadata": {"shared": "own", "landmark-id": "spoofed"}}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		id   int
+		want map[string]string
+	}{
+		{
+			name: "defaults",
+			id:   0,
+			want: map[string]string{
+				"recolude-color": "#FFFFFF",
+				"recolude-geom":  "sphere",
+				"recolude-scale": "0.5, 0.5, 0.5",
+				"shared":         "default",
+				"kept":           "yes",
+			},
+		},
+		{
+			name: "own style",
+			id:   1,
+			want: map[string]string{
+				"recolude-color": "#FF0000",
+				"recolude-geom":  "sphere",
+				"recolude-scale": "2, 2, 2",
+				"shared":         "own",
+				"kept":           "yes",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			block := model.LandmarkMetadata(tc.id)
+			for key, want := range tc.want {
+				property, ok := block.Mapping()[key]
+				if !ok || property.String() != want {
+					t.Fatalf("got %s = %v, want %q", key, property, want)
+				}
+			}
+
+			recording := format.NewRecording("0", "", []format.CaptureCollection{
+				position.NewCollection(landmark.PositionCollectionName, []position.Capture{position.NewCapture(0, 0, 0, 0)}),
+			}, nil, block, nil, nil)
+			if id, ok := landmark.ID(recording); !ok || id != tc.id {
+				t.Fatalf("got landmark ID %d (%v), want %d", id, ok, tc.id)
+			}
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	model, err := Parse(strings.NewReader(`{
+		"defaults": {"edge-color": "#00FF00", "edge-width": 0.01},
+		"landmarks": [{"name": "A"}, {"name": "B"}, {"name": "C"}],
+		"edges": [{"from": 0, "to": 1, "color": "#FF0000"}, {"from": 1, "to": 2, "width": 0.5}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := func(landmark int) string {
+		return "mark-" + string(rune('a'+landmark))
+	}
+
+	tests := []struct {
+		name     string
+		captured int
+		want     [][4]string
+	}{
+		{
+			name:     "every landmark captured",
+			captured: 3,
+			want: [][4]string{
+				{"mark-a", "mark-b", "#FF0000", "0.010000"},
+				{"mark-b", "mark-c", "#00FF00", "0.500000"},
+			},
+		},
+		{
+			name:     "edges to missing landmarks left out",
+			captured: 2,
+			want:     [][4]string{{"mark-a", "mark-b", "#FF0000", "0.010000"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := model.Lines(tc.captured, id)
+			if len(lines) != len(tc.want) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(tc.want))
+			}
+			for i, w := range tc.want {
+				got := [4]string{
+					lines[i].Mapping()["starting-object-id"].String(),
+					lines[i].Mapping()["ending-object-id"].String(),
+					lines[i].Mapping()["color"].String(),
+					lines[i].Mapping()["width"].String(),
+				}
+				if got != w {
+					t.Fatalf("line %d: got %v, want %v", i, got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestMeshVertices(t *testing.T) {
+	model := MustBuiltin("mediapipe-face")
+	if got := model.MeshVertices(); got > len(model.Landmarks) || got == 0 {
+		t.Fatalf("got %d mesh vertices for %d landmarks", got, len(model.Landmarks))
+	}
+
+	mesh := model.Mesh(func(landmark int) string { return "" })
+	if property, ok := mesh.Mapping()["type"]; !ok || property.String() != "subject-as-vertices" {
+		t.Fatalf("got mesh type %v", property)
+	}
+}
@@ -0,0 +1,71 @@
+package tracking
+
+import (
+	"sort"
+
+	"github.com/EliCDavis/vector"
+)
+
+// Match pairs every subject detected within a frame with the track it most
+// likely belongs to, as detectors make no promise that the order of subjects
+// stays the same from one frame to the next. Detections are the centers of
+// each subject found in the frame, and tracks are the centers of each subject
+// the last time they were seen. Pairs are made greedily, closest first, and
+// only if compatible (when provided) allows it. The index of the track
+// matched to each detection is returned, or -1 for detections further than
+// maxDistance from every available track.
+func Match(tracks, detections []vector.Vector3, maxDistance float64, compatible func(detection, track int) bool) []int {
+	type candidate struct {
+		detection int
+		track     int
+		distance  float64
+	}
+
+	candidates := make([]candidate, 0, len(tracks)*len(detections))
+	for detectionIndex, detection := range detections {
+		for trackIndex, track := range tracks {
+			if compatible != nil && !compatible(detectionIndex, trackIndex) {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				detection: detectionIndex,
+				track:     trackIndex,
+				distance:  detection.Distance(track),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	matches := make([]int, len(detections))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	trackTaken := make([]bool, len(tracks))
+	for _, c := range candidates {
+		if c.distance > maxDistance {
+			break
+		}
+		if matches[c.detection] != -1 || trackTaken[c.track] {
+			continue
+		}
+		matches[c.detection] = c.track
+		trackTaken[c.track] = true
+	}
+	return matches
+}
+
+// Center is the average of the provided points.
+func Center(points []vector.Vector3) vector.Vector3 {
+	center := vector.Vector3Zero()
+	if len(points) == 0 {
+		return center
+	}
+	for _, p := range points {
+		center = center.Add(p)
+	}
+	return center.DivByConstant(float64(len(points)))
+}
@@ -0,0 +1,96 @@
+package tracking
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/vector"
+)
+
+func TestMatch(t *testing.T) {
+	points := func(xs ...float64) []vector.Vector3 {
+		out := make([]vector.Vector3, len(xs))
+		for i, x := range xs {
+			out[i] = vector.NewVector3(x, 0, 0)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name        string
+		tracks      []vector.Vector3
+		detections  []vector.Vector3
+		maxDistance float64
+		compatible  func(detection, track int) bool
+		want        []int
+	}{
+		{
+			name:        "same order",
+			tracks:      points(0, 1),
+			detections:  points(0.1, 1.1),
+			maxDistance: 0.5,
+			want:        []int{0, 1},
+		},
+		{
+			name:        "swapped order",
+			tracks:      points(0, 1),
+			detections:  points(1.1, 0.1),
+			maxDistance: 0.5,
+			want:        []int{1, 0},
+		},
+		{
+			name:        "too far",
+			tracks:      points(0),
+			detections:  points(0.1, 3),
+			maxDistance: 0.5,
+			want:        []int{0, -1},
+		},
+		{
+			name:        "closest takes the track",
+			tracks:      points(0),
+			detections:  points(0.3, 0.1),
+			maxDistance: 0.5,
+			want:        []int{-1, 0},
+		},
+		{
+			name:        "no tracks",
+			tracks:      points(),
+			detections:  points(0, 1),
+			maxDistance: 0.5,
+			want:        []int{-1, -1},
+		},
+		{
+			name:        "incompatible skipped",
+			tracks:      points(0, 0.2),
+			detections:  points(0.05),
+			maxDistance: 0.5,
+			compatible: func(detection, track int) bool {
+				return track == 1
+			},
+			want: []int{1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Match(tc.tracks, tc.detections, tc.maxDistance, tc.compatible)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got matches %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got matches %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCenter(t *testing.T) {
+	if got := Center(nil); got != vector.Vector3Zero() {
+		t.Fatalf("got center %v of no points, want the origin", got)
+	}
+	got := Center([]vector.Vector3{vector.NewVector3(0, 2, 4), vector.NewVector3(2, 4, 0)})
+	if got != vector.NewVector3(1, 3, 2) {
+		t.Fatalf("got center %v, want (1, 3, 2)", got)
+	}
+}
@@ -0,0 +1,141 @@
+package tracking
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/EliCDavis/vector"
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/topology"
+	"github.com/recolude/rap/format/collection/position"
+)
+
+// CheckIDs makes sure every landmark's ID is one the model defines, as IDs
+// come straight from the input and index every landmark's captures.
+func CheckIDs(marks []landmark.LandMark, model *topology.Topology) error {
+	for _, mark := range marks {
+		if mark.ID < 0 || mark.ID >= len(model.Landmarks) {
+			return fmt.Errorf("landmark ID %d is outside of the %d landmarks of %s", mark.ID, len(model.Landmarks), model.Name)
+		}
+	}
+	return nil
+}
+
+// Subject is every capture belonging to a single subject, such as one of
+// many faces or hands, as it moves through the clip.
+type Subject struct {
+	// Captures are kept in the detector's coordinates, indexed by landmark,
+	// until the entire clip has been seen and the subject can be centered.
+	Captures [][]position.Capture
+
+	// Seen is every time the subject was detected.
+	Seen []float64
+
+	// LastSeen is the center of the subject, in the detector's coordinates,
+	// the last time the subject was detected.
+	LastSeen vector.Vector3
+
+	// smoothing is applied to the subject's landmarks before they're
+	// captured, if set. It's reset whenever the subject reappears after going
+	// undetected for longer than the max gap.
+	smoothing *filter.Stage
+}
+
+// NewSubject creates a subject made up of the provided number of landmarks,
+// smoothed by filters from the factory if it's set.
+func NewSubject(smoothing filter.Factory, landmarks int) *Subject {
+	subject := &Subject{
+		Captures: make([][]position.Capture, 0, landmarks),
+		Seen:     make([]float64, 0),
+	}
+	if smoothing != nil {
+		subject.smoothing = filter.NewStage(smoothing)
+	}
+	return subject
+}
+
+// Add captures the subject's landmarks detected within a frame that occurred
+// at the provided time, returning the landmarks as they were captured after
+// smoothing. Landmark IDs are expected to have been checked by CheckIDs.
+func (s *Subject) Add(curTime float64, marks []landmark.LandMark, maxGap float64) []landmark.LandMark {
+	captured := marks
+	if s.smoothing != nil {
+		if len(s.Seen) > 0 && curTime-s.Seen[len(s.Seen)-1] > maxGap {
+			s.smoothing.Reset()
+		}
+		captured = s.smoothing.Apply(curTime, marks)
+	}
+
+	for _, mark := range captured {
+		for len(s.Captures) < mark.ID+1 {
+			s.Captures = append(s.Captures, make([]position.Capture, 0))
+		}
+		s.Captures[mark.ID] = append(s.Captures[mark.ID], position.NewCapture(curTime, mark.X, mark.Y, mark.Z))
+	}
+
+	s.LastSeen = Centroid(marks)
+	s.Seen = append(s.Seen, curTime)
+	return captured
+}
+
+// Centered shifts every capture of the subject, which are kept in the
+// detector's coordinates, so the center of the AABB sits at the origin.
+func (s *Subject) Centered(aabb *landmark.AABB) [][]position.Capture {
+	centered := make([][]position.Capture, len(s.Captures))
+	for i, captures := range s.Captures {
+		centered[i] = make([]position.Capture, len(captures))
+		for c, capture := range captures {
+			pos := aabb.CenterPos(capture.Position().X(), capture.Position().Y(), capture.Position().Z())
+			centered[i][c] = position.NewCapture(capture.Time(), (pos.X() * 2), (-pos.Y() * 2), pos.Z()*2)
+		}
+	}
+	return centered
+}
+
+// Centroid is the center of the landmarks.
+func Centroid(marks []landmark.LandMark) vector.Vector3 {
+	points := make([]vector.Vector3, len(marks))
+	for i, mark := range marks {
+		points[i] = vector.NewVector3(mark.X, mark.Y, mark.Z)
+	}
+	return Center(points)
+}
+
+// GroupBy splits a frame into the landmarks of each subject found within it,
+// ordered by the subject ID key reads off each landmark.
+func GroupBy(frame []landmark.LandMark, key func(mark landmark.LandMark) int) [][]landmark.LandMark {
+	ids := make([]int, 0)
+	byID := make(map[int][]landmark.LandMark)
+	for _, mark := range frame {
+		id := key(mark)
+		if _, ok := byID[id]; !ok {
+			ids = append(ids, id)
+		}
+		byID[id] = append(byID[id], mark)
+	}
+
+	sort.Ints(ids)
+	groups := make([][]landmark.LandMark, len(ids))
+	for i, id := range ids {
+		groups[i] = byID[id]
+	}
+	return groups
+}
+
+// MatchSubjects pairs every subject detected within a frame with the
+// subject it most likely is, as described by Match. The index of the subject
+// matched to each detection is returned, or -1 for detections that didn't
+// match any.
+func MatchSubjects(subjects []*Subject, detections [][]landmark.LandMark, maxDistance float64, compatible func(detection, subject int) bool) []int {
+	lastSeen := make([]vector.Vector3, len(subjects))
+	for i, subject := range subjects {
+		lastSeen[i] = subject.LastSeen
+	}
+
+	centers := make([]vector.Vector3, len(detections))
+	for i, detection := range detections {
+		centers[i] = Centroid(detection)
+	}
+	return Match(lastSeen, centers, maxDistance, compatible)
+}
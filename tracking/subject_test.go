@@ -0,0 +1,165 @@
+package tracking
+
+import (
+	"testing"
+
+	"github.com/recolude/pose-recording/filter"
+	"github.com/recolude/pose-recording/landmark"
+	"github.com/recolude/pose-recording/topology"
+)
+
+func TestCheckIDs(t *testing.T) {
+	model := &topology.Topology{
+		Name:      "pair",
+		Landmarks: []topology.Landmark{{Name: "A"}, {Name: "B"}},
+	}
+
+	tests := []struct {
+		name    string
+		ids     []int
+		wantErr string
+	}{
+		{"in range", []int{0, 1}, ""},
+		{"no landmarks", []int{}, ""},
+		{"negative", []int{0, -1}, "landmark ID -1 is outside of the 2 landmarks of pair"},
+		{"too large", []int{2}, "landmark ID 2 is outside of the 2 landmarks of pair"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			marks := make([]landmark.LandMark, len(tc.ids))
+			for i, id := range tc.ids {
+				marks[i] = landmark.LandMark{ID: id}
+			}
+
+			err := CheckIDs(marks, model)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("got error %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	frame := []landmark.LandMark{
+		{ID: 0, FaceID: 2},
+		{ID: 0, FaceID: 0},
+		{ID: 1, FaceID: 2},
+		{ID: 1, FaceID: 0},
+		{ID: 0, FaceID: 1},
+	}
+
+	groups := GroupBy(frame, func(mark landmark.LandMark) int { return mark.FaceID })
+
+	want := [][2]int{{0, 2}, {1, 1}, {2, 2}}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+	for i, w := range want {
+		if groups[i][0].FaceID != w[0] || len(groups[i]) != w[1] {
+			t.Fatalf("group %d: got %v, want %d landmarks of subject %d", i, groups[i], w[1], w[0])
+		}
+		for m, mark := range groups[i] {
+			if mark.ID != m {
+				t.Fatalf("group %d: got landmarks out of order %v", i, groups[i])
+			}
+		}
+	}
+}
+
+func TestMatchSubjects(t *testing.T) {
+	left := NewSubject(nil, 1)
+	left.Add(0, []landmark.LandMark{{X: 0}, {X: 0.2}}, 1)
+	right := NewSubject(nil, 1)
+	right.Add(0, []landmark.LandMark{{X: 1}, {X: 1.2}}, 1)
+
+	got := MatchSubjects(
+		[]*Subject{left, right},
+		[][]landmark.LandMark{{{X: 1.05}, {X: 1.25}}, {{X: 0.05}}},
+		0.5,
+		nil,
+	)
+	if len(got) != 2 || got[0] != 1 || got[1] != 0 {
+		t.Fatalf("got matches %v, want [1 0]", got)
+	}
+}
+
+func TestSubjectAdd(t *testing.T) {
+	tests := []struct {
+		name      string
+		smoothing filter.Factory
+		times     []float64
+		xs        []float64
+		want      []float64
+	}{
+		{
+			name:  "unsmoothed",
+			times: []float64{0, 0.1},
+			xs:    []float64{1, 3},
+			want:  []float64{1, 3},
+		},
+		{
+			name:      "smoothed",
+			smoothing: filter.NewExponentialMovingAverage(0.5),
+			times:     []float64{0, 0.1},
+			xs:        []float64{1, 3},
+			want:      []float64{1, 2},
+		},
+		{
+			name:      "smoothing reset after a gap",
+			smoothing: filter.NewExponentialMovingAverage(0.5),
+			times:     []float64{0, 0.1, 5},
+			xs:        []float64{1, 3, 7},
+			want:      []float64{1, 2, 7},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			subject := NewSubject(tc.smoothing, 2)
+			for i, curTime := range tc.times {
+				captured := subject.Add(curTime, []landmark.LandMark{{ID: 1, X: tc.xs[i]}}, 0.5)
+				if captured[0].X != tc.want[i] {
+					t.Fatalf("frame %d: got x %g, want %g", i, captured[0].X, tc.want[i])
+				}
+			}
+
+			if len(subject.Captures) != 2 || len(subject.Captures[0]) != 0 || len(subject.Captures[1]) != len(tc.times) {
+				t.Fatalf("got captures %v, want %d of landmark 1 alone", subject.Captures, len(tc.times))
+			}
+			for i, capture := range subject.Captures[1] {
+				if capture.Time() != tc.times[i] || capture.Position().X() != tc.want[i] {
+					t.Fatalf("capture %d: got %v, want x %g at %g", i, capture, tc.want[i], tc.times[i])
+				}
+			}
+			if len(subject.Seen) != len(tc.times) || subject.LastSeen.X() != tc.xs[len(tc.xs)-1] {
+				t.Fatalf("got seen %v last at %v", subject.Seen, subject.LastSeen)
+			}
+		})
+	}
+}
+
+func TestSubjectCentered(t *testing.T) {
+	subject := NewSubject(nil, 1)
+	subject.Add(0, []landmark.LandMark{{X: 0, Y: 0, Z: 0}}, 1)
+	subject.Add(1, []landmark.LandMark{{X: 1, Y: 1, Z: 1}}, 1)
+
+	aabb := landmark.NewAABB()
+	aabb.Encompass(0, 0, 0)
+	aabb.Encompass(1, 1, 1)
+
+	centered := subject.Centered(aabb)
+	first := centered[0][0].Position()
+	if first.X() != -1 || first.Y() != 1 || first.Z() != -1 {
+		t.Fatalf("got %v, want the corner flipped about the center", first)
+	}
+	if centered[0][1].Time() != 1 {
+		t.Fatalf("got time %g, want 1", centered[0][1].Time())
+	}
+}
@@ -0,0 +1,83 @@
+// Package tracking finds the stretches of time a detector lost sight of a
+// subject, and builds the captures used to show those stretches within a
+// recording.
+package tracking
+
+import (
+	"github.com/recolude/rap/format/collection/event"
+	"github.com/recolude/rap/format/metadata"
+)
+
+const (
+	// LostEvent is the name of the event emitted at the last moment a subject
+	// was seen before a gap.
+	LostEvent = "tracking-lost"
+
+	// RegainedEvent is the name of the event emitted the moment a subject is
+	// seen again after a gap.
+	RegainedEvent = "tracking-regained"
+
+	// CollectionName is the name given to the event collection of gaps.
+	CollectionName = "Tracking"
+)
+
+// Gap is a stretch of time the detector failed to find a subject.
+type Gap struct {
+	// Lost is the last time the subject was seen before the gap.
+	Lost float64
+
+	// Regained is the first time the subject was seen after the gap.
+	Regained float64
+}
+
+// Duration is how long the subject went unseen.
+func (g Gap) Duration() float64 {
+	return g.Regained - g.Lost
+}
+
+// MaxGap is the longest two detections can be apart at the provided frame
+// rate before the subject is considered lost. Half a frame of leeway is given
+// to absorb jitter in timestamps.
+func MaxGap(fps float64) float64 {
+	return 1.5 / fps
+}
+
+// ResolveMaxGap is the max gap a converter was configured with, or the
+// MaxGap of the frame rate if it was left zero.
+func ResolveMaxGap(maxGap, fps float64) float64 {
+	if maxGap > 0 {
+		return maxGap
+	}
+	return MaxGap(fps)
+}
+
+// FindGaps looks through the ordered times a subject was detected for any two
+// detections further apart than maxGap.
+func FindGaps(times []float64, maxGap float64) []Gap {
+	gaps := make([]Gap, 0)
+	for i := 1; i < len(times); i++ {
+		if times[i]-times[i-1] > maxGap {
+			gaps = append(gaps, Gap{Lost: times[i-1], Regained: times[i]})
+		}
+	}
+	return gaps
+}
+
+// Events builds a pair of lost and regained events for every gap. Both events
+// carry the duration of the gap in seconds.
+func Events(gaps []Gap) []event.Capture {
+	captures := make([]event.Capture, 0, len(gaps)*2)
+	for _, gap := range gaps {
+		duration := metadata.NewFloat32Property(float32(gap.Duration()))
+		captures = append(
+			captures,
+			event.NewCapture(gap.Lost, LostEvent, metadata.NewBlock(map[string]metadata.Property{
+				"duration": duration,
+			})),
+			event.NewCapture(gap.Regained, RegainedEvent, metadata.NewBlock(map[string]metadata.Property{
+				"duration": duration,
+			})),
+		)
+	}
+	return captures
+}
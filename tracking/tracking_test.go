@@ -0,0 +1,86 @@
+package tracking
+
+import (
+	"testing"
+)
+
+func TestFindGaps(t *testing.T) {
+	tests := []struct {
+		name   string
+		times  []float64
+		maxGap float64
+		want   []Gap
+	}{
+		{"no detections", []float64{}, 0.05, []Gap{}},
+		{"single detection", []float64{1}, 0.05, []Gap{}},
+		{"steady", []float64{0, 0.04, 0.08, 0.12}, 0.05, []Gap{}},
+		{"exactly max gap", []float64{0, 0.5}, 0.5, []Gap{}},
+		{"one gap", []float64{0, 0.04, 1, 1.04}, 0.05, []Gap{{Lost: 0.04, Regained: 1}}},
+		{
+			name:   "many gaps",
+			times:  []float64{0, 1, 1.04, 3},
+			maxGap: 0.05,
+			want:   []Gap{{Lost: 0, Regained: 1}, {Lost: 1.04, Regained: 3}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FindGaps(tc.times, tc.maxGap)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got gaps %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got gaps %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveMaxGap(t *testing.T) {
+	tests := []struct {
+		name   string
+		maxGap float64
+		fps    float64
+		want   float64
+	}{
+		{"from frame rate", 0, 30, 0.05},
+		{"configured", 0.25, 30, 0.25},
+		{"negative falls back", -1, 10, 0.15},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolveMaxGap(tc.maxGap, tc.fps); got != tc.want {
+				t.Fatalf("got %g, want %g", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	events := Events([]Gap{{Lost: 1, Regained: 3.5}, {Lost: 4, Regained: 4.25}})
+
+	want := []struct {
+		time     float64
+		name     string
+		duration string
+	}{
+		{1, LostEvent, "2.500000"},
+		{3.5, RegainedEvent, "2.500000"},
+		{4, LostEvent, "0.250000"},
+		{4.25, RegainedEvent, "0.250000"},
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Time() != w.time || e.Name() != w.name || e.Metadata().Mapping()["duration"].String() != w.duration {
+			t.Fatalf("event %d: got %s at %g lasting %v, want %+v", i, e.Name(), e.Time(), e.Metadata().Mapping()["duration"], w)
+		}
+	}
+}